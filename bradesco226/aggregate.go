@@ -0,0 +1,166 @@
+package bradesco226
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrDuplicateContract   = errors.New("contract number appears in more than one contract")
+	ErrOrphanRecord        = errors.New("record references a contract not in the file")
+	ErrInstallmentSequence = errors.New("installment numbers are not contiguous up to the contract installments")
+)
+
+// ContractAggregate is a contract with the borrowers and installments referencing its ContractNumber.
+type ContractAggregate struct {
+	Contract     Contract
+	Borrowers    []Borrower
+	Installments []Installment // Installments are sorted by InstallmentNumber.
+}
+
+// ContractTotals sums the installments of a contract.
+type ContractTotals struct {
+	Installments      int             // Installments is the number of installments.
+	Amount            decimal.Decimal // Amount is the sum of the installment amounts.
+	DailyDefaultValue decimal.Decimal // DailyDefaultValue is the sum of the installment daily default values.
+}
+
+// Totals sums the installments of the contract.
+func (c ContractAggregate) Totals() ContractTotals {
+	totals := ContractTotals{Amount: decimal.Zero, DailyDefaultValue: decimal.Zero}
+
+	for _, installment := range c.Installments {
+		totals.Installments++
+		totals.Amount = totals.Amount.Add(installment.Amount)
+		totals.DailyDefaultValue = totals.DailyDefaultValue.Add(installment.DailyDefaultValue)
+	}
+
+	return totals
+}
+
+// GroupContracts groups the records of a file by contract, checking that every borrower and
+// installment references a contract of the file and that the installment numbers of each
+// contract run from 1 up to its Installments, without gaps or repetitions.
+//
+// Parameters:
+//   - records: The records returned by ReadFile or Parse, in any order. Headers are ignored.
+//
+// Returns:
+//   - []ContractAggregate: The contracts, in the order they appear in records.
+//   - error: ErrDuplicateContract, ErrOrphanRecord or ErrInstallmentSequence, with the contract number.
+//
+// Example:
+//
+//	contracts, err := GroupContracts(records)
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	amount := contracts[0].Totals().Amount
+func GroupContracts(records []interface{}) ([]ContractAggregate, error) {
+	var contracts []ContractAggregate
+
+	indexes := map[string]int{}
+
+	for _, record := range records {
+		if contract, ok := record.(Contract); ok {
+			if _, ok := indexes[contract.ContractNumber]; ok {
+				return nil, wraperrors.NewErrWrap(ErrDuplicateContract, fmt.Errorf("at contract %s", contract.ContractNumber))
+			}
+
+			indexes[contract.ContractNumber] = len(contracts)
+			contracts = append(contracts, ContractAggregate{Contract: contract})
+		}
+	}
+
+	for _, record := range records {
+		switch record := record.(type) {
+		case Borrower:
+			index, ok := indexes[record.ContractNumber]
+
+			if !ok {
+				return nil, wraperrors.NewErrWrap(ErrOrphanRecord, fmt.Errorf("at borrower %q of contract %s", record.Name, record.ContractNumber))
+			}
+
+			contracts[index].Borrowers = append(contracts[index].Borrowers, record)
+		case Installment:
+			index, ok := indexes[record.ContractNumber]
+
+			if !ok {
+				return nil, wraperrors.NewErrWrap(ErrOrphanRecord, fmt.Errorf("at installment %s of contract %s", record.InstallmentNumber, record.ContractNumber))
+			}
+
+			contracts[index].Installments = append(contracts[index].Installments, record)
+		}
+	}
+
+	for i := range contracts {
+		if err := contracts[i].sortInstallments(); err != nil {
+			return nil, wraperrors.NewErrWrap(err, fmt.Errorf("at contract %s", contracts[i].Contract.ContractNumber))
+		}
+	}
+
+	return contracts, nil
+}
+
+// ReadContracts reads a file with ReadFile and groups its records with GroupContracts.
+func ReadContracts(r io.Reader) ([]ContractAggregate, error) {
+	records, err := ReadFile(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupContracts(records)
+}
+
+// sortInstallments sorts the installments by number, checking they run from 1 up to the contract Installments.
+func (c *ContractAggregate) sortInstallments() error {
+	expected, err := strconv.Atoi(c.Contract.Installments)
+
+	if err != nil {
+		return wraperrors.NewErrWrap(ErrInstallmentSequence, fmt.Errorf("contract installments %q", c.Contract.Installments))
+	}
+
+	numbers := make([]int, len(c.Installments))
+
+	for i, installment := range c.Installments {
+		if numbers[i], err = strconv.Atoi(installment.InstallmentNumber); err != nil {
+			return wraperrors.NewErrWrap(ErrInstallmentSequence, fmt.Errorf("installment number %q", installment.InstallmentNumber))
+		}
+	}
+
+	sort.Sort(installmentsByNumber{installments: c.Installments, numbers: numbers})
+
+	for i, number := range numbers {
+		if number != i+1 {
+			return wraperrors.NewErrWrap(ErrInstallmentSequence, fmt.Errorf("installment %d found where %d was expected", number, i+1))
+		}
+	}
+
+	if len(numbers) != expected {
+		return wraperrors.NewErrWrap(ErrInstallmentSequence, fmt.Errorf("%d installments for a contract of %d", len(numbers), expected))
+	}
+
+	return nil
+}
+
+// installmentsByNumber sorts installments and their parsed numbers together.
+type installmentsByNumber struct {
+	installments []Installment
+	numbers      []int
+}
+
+func (s installmentsByNumber) Len() int { return len(s.numbers) }
+
+func (s installmentsByNumber) Less(i, j int) bool { return s.numbers[i] < s.numbers[j] }
+
+func (s installmentsByNumber) Swap(i, j int) {
+	s.installments[i], s.installments[j] = s.installments[j], s.installments[i]
+	s.numbers[i], s.numbers[j] = s.numbers[j], s.numbers[i]
+}
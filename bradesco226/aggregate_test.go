@@ -0,0 +1,76 @@
+package bradesco226
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadContracts(t *testing.T) {
+	lines := []string{headerLine, contractLine, borrowerLine, installmentLine2, installmentLine1}
+
+	contracts, err := ReadContracts(strings.NewReader(strings.Join(lines, "\n")))
+
+	assert.NoError(t, err)
+	assert.Len(t, contracts, 1)
+	assert.Equal(t, "000054321", contracts[0].Contract.ContractNumber)
+	assert.Len(t, contracts[0].Borrowers, 1)
+	assert.Equal(t, "001", contracts[0].Installments[0].InstallmentNumber)
+	assert.Equal(t, "002", contracts[0].Installments[1].InstallmentNumber)
+	assert.Equal(t, 2, contracts[0].Totals().Installments)
+	assert.True(t, decimal.RequireFromString("10000").Equal(contracts[0].Totals().Amount))
+}
+
+func TestGroupContractsErrors(t *testing.T) {
+	contract := Contract{RegisterType: "2", ContractNumber: "000000001", Installments: "002"}
+	installment := func(contractNumber, number string) Installment {
+		return Installment{RegisterType: "4", ContractNumber: contractNumber, InstallmentNumber: number}
+	}
+
+	tests := []struct {
+		name    string
+		records []interface{}
+		wantErr error
+	}{
+		{
+			name:    "installment of a missing contract",
+			records: []interface{}{contract, installment("000000001", "001"), installment("000000001", "002"), installment("000000002", "001")},
+			wantErr: ErrOrphanRecord,
+		},
+		{
+			name:    "borrower of a missing contract",
+			records: []interface{}{contract, Borrower{RegisterType: "3", ContractNumber: "000000002"}, installment("000000001", "001"), installment("000000001", "002")},
+			wantErr: ErrOrphanRecord,
+		},
+		{
+			name:    "repeated contract",
+			records: []interface{}{contract, contract},
+			wantErr: ErrDuplicateContract,
+		},
+		{
+			name:    "gap in installment numbers",
+			records: []interface{}{contract, installment("000000001", "001"), installment("000000001", "003")},
+			wantErr: ErrInstallmentSequence,
+		},
+		{
+			name:    "repeated installment number",
+			records: []interface{}{contract, installment("000000001", "001"), installment("000000001", "001")},
+			wantErr: ErrInstallmentSequence,
+		},
+		{
+			name:    "fewer installments than the contract",
+			records: []interface{}{contract, installment("000000001", "001")},
+			wantErr: ErrInstallmentSequence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GroupContracts(tt.records)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
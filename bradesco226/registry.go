@@ -0,0 +1,71 @@
+package bradesco226
+
+import (
+	"fmt"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBradesco226,
+		RecordLength: 226,
+		Kind: func(line string) (string, error) {
+			kind, err := Kind(line)
+
+			return string(kind), err
+		},
+		Records: map[string]documenttranslator.Record{
+			string(RegisterTypeHeader):      Header{},
+			string(RegisterTypeContract):    Contract{},
+			string(RegisterTypeBorrower):    Borrower{},
+			string(RegisterTypeInstallment): Installment{},
+		},
+		Parse: func(line string) (documenttranslator.Record, error) {
+			record, err := Parse(line)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return record.(documenttranslator.Record), nil
+		},
+		Finalize: finalize,
+	})
+}
+
+// finalize fills the header of an imported file with the quantities of its contracts,
+// borrowers and installments.
+func finalize(records []documenttranslator.Record) ([]documenttranslator.Record, error) {
+	if len(records) == 0 {
+		return nil, ErrMisplacedHeader
+	}
+
+	header, ok := records[0].(Header)
+
+	if !ok {
+		return nil, wraperrors.NewErrWrap(ErrMisplacedHeader, fmt.Errorf("at record 1"))
+	}
+
+	quantities := map[RegisterType]int{}
+
+	for i, record := range records[1:] {
+		switch record.(type) {
+		case Header:
+			return nil, wraperrors.NewErrWrap(ErrMisplacedHeader, fmt.Errorf("at record %d", i+2))
+		case Contract:
+			quantities[RegisterTypeContract]++
+		case Borrower:
+			quantities[RegisterTypeBorrower]++
+		case Installment:
+			quantities[RegisterTypeInstallment]++
+		}
+	}
+
+	header.ContractQuantity = fmt.Sprintf("%09d", quantities[RegisterTypeContract])
+	header.BorrowerQuantity = fmt.Sprintf("%09d", quantities[RegisterTypeBorrower])
+	header.InstallmentQuantity = fmt.Sprintf("%09d", quantities[RegisterTypeInstallment])
+
+	return append([]documenttranslator.Record{header}, records[1:]...), nil
+}
@@ -0,0 +1,19 @@
+package bradesco226
+
+import (
+	"testing"
+
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestRoundTripRandomRecords(t *testing.T) {
+	roundtrip.Check(t, Header{}, 200, nil)
+	roundtrip.Check(t, Contract{}, 200, func(record interface{}) {
+		contract := record.(*Contract)
+		contract.BorrowerDocumentNumber = "529982247"
+		contract.BorrowerFilial = "0000"
+		contract.BorrowerDocumentControl = "25"
+	})
+	roundtrip.Check(t, Borrower{}, 200, nil)
+	roundtrip.Check(t, Installment{}, 200, nil)
+}
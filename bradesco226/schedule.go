@@ -0,0 +1,359 @@
+package bradesco226
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInconsistentSchedule = errors.New("installments disagree with the contract terms")
+	ErrUnknownAmortization  = errors.New("unknown amortization system")
+)
+
+// Amortization is the system a contract schedule is computed with.
+type Amortization string
+
+const (
+	AmortizationPrice Amortization = "price" // AmortizationPrice has installments of constant amount.
+	AmortizationSAC   Amortization = "sac"   // AmortizationSAC has installments of constant amortization.
+)
+
+// RatePeriod is the period the contract InterestTax refers to.
+type RatePeriod string
+
+const (
+	RatePeriodMonthly RatePeriod = "monthly"
+	RatePeriodYearly  RatePeriod = "yearly"
+)
+
+// defaultTolerance is the difference accepted between an expected and a declared amount.
+var defaultTolerance = decimal.RequireFromString("0.01")
+
+// ScheduleOptions holds the contract terms not declared in the file.
+type ScheduleOptions struct {
+	Amortization Amortization    // Amortization is the schedule system, AmortizationPrice when empty.
+	RatePeriod   RatePeriod      // RatePeriod is the period of InterestTax, a percentage, RatePeriodMonthly when empty.
+	FinanceFees  bool            // FinanceFees adds IOF and TAC to the financed principal.
+	Tolerance    decimal.Decimal // Tolerance is the difference accepted for each installment amount, 0.01 when zero.
+
+	// FirstDueDate returns the agreed due date of the first installment of each contract, such
+	// as one after a grace period. When nil or returning a zero time, the first installment is
+	// due a month after the contract Date.
+	FirstDueDate func(contract ContractAggregate) time.Time
+}
+
+// ScheduledInstallment is an installment of the schedule recomputed from the contract terms.
+type ScheduledInstallment struct {
+	Number       int
+	DueDate      time.Time
+	Amount       decimal.Decimal
+	Interest     decimal.Decimal
+	Amortization decimal.Decimal
+	Balance      decimal.Decimal // Balance is the principal still owed after the installment.
+}
+
+// ScheduleDiscrepancy is a value of a contract or installment that disagrees with the recomputed schedule.
+type ScheduleDiscrepancy struct {
+	ContractNumber    string
+	InstallmentNumber int    // InstallmentNumber is zero for discrepancies of the whole contract.
+	Field             string // Field is the disagreeing field, e.g. "Amount", "DueDate", "Installments" or "Total".
+	Expected          string
+	Found             string
+}
+
+func (d ScheduleDiscrepancy) Error() string {
+	if d.InstallmentNumber == 0 {
+		return fmt.Sprintf("contract %s %s is %s, expected %s", d.ContractNumber, d.Field, d.Found, d.Expected)
+	}
+
+	return fmt.Sprintf("contract %s installment %d %s is %s, expected %s", d.ContractNumber, d.InstallmentNumber, d.Field, d.Found, d.Expected)
+}
+
+// ScheduleDiscrepancies holds every discrepancy found in the contracts checked.
+type ScheduleDiscrepancies []ScheduleDiscrepancy
+
+func (s ScheduleDiscrepancies) Error() string {
+	var messages []string
+
+	for _, discrepancy := range s {
+		messages = append(messages, discrepancy.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (s ScheduleDiscrepancies) Is(target error) bool {
+	return target == ErrInconsistentSchedule
+}
+
+// ExpectedSchedule recomputes the installments of a contract from its ContractValue, InterestTax
+// and Installments, due monthly from firstDueDate. When firstDueDate falls whole months later
+// than a month after the contract Date, the interest of that grace period is added to the
+// principal before the installments are computed.
+//
+// Parameters:
+//   - contract: The contract terms.
+//   - firstDueDate: The due date of the first installment. Later installments are due on the same day of the following months.
+//   - options: The terms not declared in the file.
+//
+// Returns:
+//   - []ScheduledInstallment: The installments, from number 1.
+//   - error: ErrUnknownAmortization, or ErrInconsistentSchedule if Installments is not a positive number.
+//
+// Example:
+//
+//	schedule, err := ExpectedSchedule(contract, firstDueDate, ScheduleOptions{Amortization: AmortizationSAC})
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ExpectedSchedule(contract Contract, firstDueDate time.Time, options ScheduleOptions) ([]ScheduledInstallment, error) {
+	count, err := strconv.Atoi(contract.Installments)
+
+	if err != nil || count <= 0 {
+		return nil, wraperrors.NewErrWrap(ErrInconsistentSchedule, fmt.Errorf("contract %s has invalid installments %q", contract.ContractNumber, contract.Installments))
+	}
+
+	principal := contract.ContractValue
+
+	if options.FinanceFees {
+		principal = principal.Add(contract.IOF).Add(contract.TAC)
+	}
+
+	rate := monthlyRate(contract.InterestTax, options.RatePeriod)
+
+	if grace := graceMonths(contract.Date, firstDueDate); grace > 0 {
+		principal = principal.Mul(decimal.NewFromInt(1).Add(rate).Pow(decimal.NewFromInt(int64(grace)))).Round(2)
+	}
+
+	var payment, constantAmortization decimal.Decimal
+
+	switch options.Amortization {
+	case AmortizationPrice, "":
+		payment = pricePayment(principal, rate, count)
+	case AmortizationSAC:
+		constantAmortization = principal.Div(decimal.NewFromInt(int64(count))).Round(2)
+	default:
+		return nil, wraperrors.NewErrWrap(ErrUnknownAmortization, fmt.Errorf("amortization %q", options.Amortization))
+	}
+
+	schedule := make([]ScheduledInstallment, count)
+	balance := principal
+
+	for i := range schedule {
+		interest := balance.Mul(rate).Round(2)
+		amortization := constantAmortization
+
+		if options.Amortization != AmortizationSAC {
+			amortization = payment.Sub(interest)
+		}
+
+		// The last installment settles what rounding left of the principal.
+		if i == count-1 {
+			amortization = balance
+		}
+
+		balance = balance.Sub(amortization)
+		schedule[i] = ScheduledInstallment{
+			Number:       i + 1,
+			DueDate:      addMonths(firstDueDate, i),
+			Amount:       amortization.Add(interest),
+			Interest:     interest,
+			Amortization: amortization,
+			Balance:      balance,
+		}
+	}
+
+	return schedule, nil
+}
+
+// CheckSchedule compares the installments of a contract with the schedule recomputed from
+// its terms, flagging installment amounts and due dates outside the tolerance, a number of
+// installments other than the declared Installments, a first installment due before the
+// contract date and a total disagreeing with the recomputed one.
+//
+// The schedule is due monthly from the date options.FirstDueDate returns for the contract, or a
+// month after the contract Date. Contracts with neither are only checked against the due date of
+// their first installment.
+//
+// Returns nil when the contract is consistent, or ScheduleDiscrepancies, which matches
+// ErrInconsistentSchedule with errors.Is.
+func CheckSchedule(contract ContractAggregate, options ScheduleOptions) error {
+	var discrepancies ScheduleDiscrepancies
+
+	number := contract.Contract.ContractNumber
+	flag := func(installment int, field string, expected, found interface{}) {
+		discrepancies = append(discrepancies, ScheduleDiscrepancy{
+			ContractNumber:    number,
+			InstallmentNumber: installment,
+			Field:             field,
+			Expected:          fmt.Sprint(expected),
+			Found:             fmt.Sprint(found),
+		})
+	}
+
+	if len(contract.Installments) == 0 {
+		flag(0, "Installments", contract.Contract.Installments, 0)
+		return discrepancies
+	}
+
+	schedule, err := ExpectedSchedule(contract.Contract, expectedFirstDueDate(contract, options), options)
+
+	if err != nil {
+		return err
+	}
+
+	if len(contract.Installments) != len(schedule) {
+		flag(0, "Installments", len(schedule), len(contract.Installments))
+	}
+
+	if firstDueDate := contract.Installments[0].DueDate; !contract.Contract.Date.IsZero() && firstDueDate.Before(contract.Contract.Date) {
+		flag(1, "DueDate", "after "+contract.Contract.Date.Format("02/01/2006"), firstDueDate.Format("02/01/2006"))
+	}
+
+	tolerance := options.Tolerance
+
+	if tolerance.IsZero() {
+		tolerance = defaultTolerance
+	}
+
+	expectedTotal, foundTotal := decimal.Zero, decimal.Zero
+
+	for i, expected := range schedule {
+		expectedTotal = expectedTotal.Add(expected.Amount)
+
+		if i >= len(contract.Installments) {
+			continue
+		}
+
+		installment := contract.Installments[i]
+		foundTotal = foundTotal.Add(installment.Amount)
+
+		if installment.Amount.Sub(expected.Amount).Abs().GreaterThan(tolerance) {
+			flag(expected.Number, "Amount", expected.Amount.StringFixed(2), installment.Amount.StringFixed(2))
+		}
+
+		if !installment.DueDate.Equal(expected.DueDate) {
+			flag(expected.Number, "DueDate", expected.DueDate.Format("02/01/2006"), installment.DueDate.Format("02/01/2006"))
+		}
+	}
+
+	if len(contract.Installments) > len(schedule) {
+		for _, installment := range contract.Installments[len(schedule):] {
+			foundTotal = foundTotal.Add(installment.Amount)
+		}
+	}
+
+	totalTolerance := tolerance.Mul(decimal.NewFromInt(int64(len(schedule))))
+
+	if foundTotal.Sub(expectedTotal).Abs().GreaterThan(totalTolerance) {
+		flag(0, "Total", expectedTotal.StringFixed(2), foundTotal.StringFixed(2))
+	}
+
+	if len(discrepancies) > 0 {
+		return discrepancies
+	}
+
+	return nil
+}
+
+// CheckSchedules checks every contract with CheckSchedule, returning the discrepancies of all of them.
+func CheckSchedules(contracts []ContractAggregate, options ScheduleOptions) error {
+	var discrepancies ScheduleDiscrepancies
+
+	for _, contract := range contracts {
+		err := CheckSchedule(contract, options)
+
+		var found ScheduleDiscrepancies
+
+		if errors.As(err, &found) {
+			discrepancies = append(discrepancies, found...)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(discrepancies) > 0 {
+		return discrepancies
+	}
+
+	return nil
+}
+
+// expectedFirstDueDate returns the due date the first installment of a contract was agreed for.
+func expectedFirstDueDate(contract ContractAggregate, options ScheduleOptions) time.Time {
+	if options.FirstDueDate != nil {
+		if firstDueDate := options.FirstDueDate(contract); !firstDueDate.IsZero() {
+			return firstDueDate
+		}
+	}
+
+	if !contract.Contract.Date.IsZero() {
+		return addMonths(contract.Contract.Date, 1)
+	}
+
+	return contract.Installments[0].DueDate
+}
+
+// graceMonths counts the whole months firstDueDate falls after a month past the contract date.
+func graceMonths(contractDate time.Time, firstDueDate time.Time) int {
+	if contractDate.IsZero() {
+		return 0
+	}
+
+	months := 0
+
+	for !addMonths(contractDate, months+2).After(firstDueDate) {
+		months++
+	}
+
+	return months
+}
+
+// monthlyRate converts the InterestTax percentage to a monthly rate.
+func monthlyRate(interestTax decimal.Decimal, period RatePeriod) decimal.Decimal {
+	rate := interestTax.Div(decimal.NewFromInt(100))
+
+	if period != RatePeriodYearly {
+		return rate
+	}
+
+	yearly, _ := rate.Float64()
+
+	return decimal.NewFromFloat(math.Pow(1+yearly, 1.0/12) - 1)
+}
+
+// pricePayment is the constant installment amount of the Price system.
+func pricePayment(principal decimal.Decimal, rate decimal.Decimal, count int) decimal.Decimal {
+	n := decimal.NewFromInt(int64(count))
+
+	if rate.IsZero() {
+		return principal.Div(n).Round(2)
+	}
+
+	factor := decimal.NewFromInt(1).Add(rate).Pow(n)
+
+	return principal.Mul(rate).Mul(factor).Div(factor.Sub(decimal.NewFromInt(1))).Round(2)
+}
+
+// addMonths adds months to date, keeping its day or the last day of shorter months.
+func addMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := date.Day()
+
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
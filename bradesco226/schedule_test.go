@@ -0,0 +1,183 @@
+package bradesco226
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func scheduledContract(value string, installments []string, dueDates ...time.Time) ContractAggregate {
+	contract := ContractAggregate{
+		Contract: Contract{
+			ContractNumber: "000054321",
+			Date:           time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+			ContractValue:  decimal.RequireFromString(value),
+			InterestTax:    decimal.RequireFromString("1"),
+			Installments:   "003",
+		},
+	}
+
+	for i, amount := range installments {
+		contract.Installments = append(contract.Installments, Installment{
+			ContractNumber:    "000054321",
+			InstallmentNumber: "00" + string(rune('1'+i)),
+			DueDate:           dueDates[i],
+			Amount:            decimal.RequireFromString(amount),
+		})
+	}
+
+	return contract
+}
+
+func monthly(first time.Time, count int) []time.Time {
+	var dates []time.Time
+
+	for i := 0; i < count; i++ {
+		dates = append(dates, first.AddDate(0, i, 0))
+	}
+
+	return dates
+}
+
+func on(date time.Time) func(ContractAggregate) time.Time {
+	return func(ContractAggregate) time.Time {
+		return date
+	}
+}
+
+func TestExpectedSchedule(t *testing.T) {
+	firstDueDate := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	price, err := ExpectedSchedule(Contract{ContractValue: decimal.RequireFromString("1000"), InterestTax: decimal.RequireFromString("1"), Installments: "003"}, firstDueDate, ScheduleOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "340.02", price[0].Amount.StringFixed(2))
+	assert.Equal(t, "10.00", price[0].Interest.StringFixed(2))
+	assert.Equal(t, "340.02", price[1].Amount.StringFixed(2))
+	assert.Equal(t, "340.03", price[2].Amount.StringFixed(2))
+	assert.True(t, price[2].Balance.IsZero())
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), price[1].DueDate)
+	assert.Equal(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), price[2].DueDate)
+
+	sac, err := ExpectedSchedule(Contract{ContractValue: decimal.RequireFromString("900"), InterestTax: decimal.RequireFromString("1"), Installments: "003"}, firstDueDate, ScheduleOptions{Amortization: AmortizationSAC})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "309.00", sac[0].Amount.StringFixed(2))
+	assert.Equal(t, "306.00", sac[1].Amount.StringFixed(2))
+	assert.Equal(t, "303.00", sac[2].Amount.StringFixed(2))
+
+	_, err = ExpectedSchedule(Contract{Installments: "003"}, firstDueDate, ScheduleOptions{Amortization: "german"})
+	assert.ErrorIs(t, err, ErrUnknownAmortization)
+}
+
+func TestCheckSchedule(t *testing.T) {
+	firstDueDate := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		contract ContractAggregate
+		options  ScheduleOptions
+		expected ScheduleDiscrepancies
+	}{
+		{
+			name:     "consistent price contract",
+			contract: scheduledContract("1000", []string{"340.02", "340.02", "340.03"}, monthly(firstDueDate, 3)...),
+		},
+		{
+			name:     "consistent sac contract",
+			contract: scheduledContract("900", []string{"309.00", "306.00", "303.00"}, monthly(firstDueDate, 3)...),
+			options:  ScheduleOptions{Amortization: AmortizationSAC},
+		},
+		{
+			name:     "amounts within tolerance",
+			contract: scheduledContract("1000", []string{"340.00", "340.00", "340.00"}, monthly(firstDueDate, 3)...),
+			options:  ScheduleOptions{Tolerance: decimal.RequireFromString("0.05")},
+		},
+		{
+			name:     "wrong amount",
+			contract: scheduledContract("1000", []string{"340.02", "350.02", "340.03"}, monthly(firstDueDate, 3)...),
+			expected: ScheduleDiscrepancies{
+				{ContractNumber: "000054321", InstallmentNumber: 2, Field: "Amount", Expected: "340.02", Found: "350.02"},
+				{ContractNumber: "000054321", Field: "Total", Expected: "1020.07", Found: "1030.07"},
+			},
+		},
+		{
+			name: "skipped month",
+			contract: scheduledContract("1000", []string{"340.02", "340.02", "340.03"},
+				firstDueDate, firstDueDate.AddDate(0, 2, 0), firstDueDate.AddDate(0, 3, 0)),
+			expected: ScheduleDiscrepancies{
+				{ContractNumber: "000054321", InstallmentNumber: 2, Field: "DueDate", Expected: "10/03/2024", Found: "10/04/2024"},
+				{ContractNumber: "000054321", InstallmentNumber: 3, Field: "DueDate", Expected: "10/04/2024", Found: "10/05/2024"},
+			},
+		},
+		{
+			name:     "missing installment",
+			contract: scheduledContract("1000", []string{"340.02", "340.02"}, monthly(firstDueDate, 2)...),
+			expected: ScheduleDiscrepancies{
+				{ContractNumber: "000054321", Field: "Installments", Expected: "3", Found: "2"},
+				{ContractNumber: "000054321", Field: "Total", Expected: "1020.07", Found: "680.04"},
+			},
+		},
+		{
+			name:     "installments shifted from the contract date",
+			contract: scheduledContract("1000", []string{"340.02", "340.02", "340.03"}, monthly(firstDueDate.AddDate(0, 1, 0), 3)...),
+			expected: ScheduleDiscrepancies{
+				{ContractNumber: "000054321", InstallmentNumber: 1, Field: "DueDate", Expected: "10/02/2024", Found: "10/03/2024"},
+				{ContractNumber: "000054321", InstallmentNumber: 2, Field: "DueDate", Expected: "10/03/2024", Found: "10/04/2024"},
+				{ContractNumber: "000054321", InstallmentNumber: 3, Field: "DueDate", Expected: "10/04/2024", Found: "10/05/2024"},
+			},
+		},
+		{
+			name:     "agreed first due date after a grace period",
+			contract: scheduledContract("1000", []string{"343.42", "343.42", "343.43"}, monthly(firstDueDate.AddDate(0, 1, 0), 3)...),
+			options:  ScheduleOptions{FirstDueDate: on(firstDueDate.AddDate(0, 1, 0))},
+		},
+		{
+			name:     "first installment due before the contract",
+			contract: scheduledContract("1000", []string{"340.02", "340.02", "340.03"}, monthly(firstDueDate.AddDate(0, -2, 0), 3)...),
+			options:  ScheduleOptions{FirstDueDate: on(firstDueDate.AddDate(0, -2, 0))},
+			expected: ScheduleDiscrepancies{
+				{ContractNumber: "000054321", InstallmentNumber: 1, Field: "DueDate", Expected: "after 10/01/2024", Found: "10/12/2023"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSchedule(tt.contract, tt.options)
+
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrInconsistentSchedule)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestCheckSchedules(t *testing.T) {
+	firstDueDate := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	consistent := scheduledContract("1000", []string{"340.02", "340.02", "340.03"}, monthly(firstDueDate, 3)...)
+	inconsistent := scheduledContract("1000", []string{"340.02", "350.02", "340.03"}, monthly(firstDueDate, 3)...)
+
+	assert.NoError(t, CheckSchedules([]ContractAggregate{consistent, consistent}, ScheduleOptions{}))
+
+	err := CheckSchedules([]ContractAggregate{consistent, inconsistent}, ScheduleOptions{})
+
+	assert.ErrorIs(t, err, ErrInconsistentSchedule)
+	assert.Len(t, err.(ScheduleDiscrepancies), 2)
+
+	grace := scheduledContract("1000", []string{"343.42", "343.42", "343.43"}, monthly(firstDueDate.AddDate(0, 1, 0), 3)...)
+	grace.Contract.ContractNumber = "000054322"
+	agreed := map[string]time.Time{"000054322": firstDueDate.AddDate(0, 1, 0)}
+
+	assert.NoError(t, CheckSchedules([]ContractAggregate{consistent, grace}, ScheduleOptions{
+		FirstDueDate: func(contract ContractAggregate) time.Time {
+			return agreed[contract.Contract.ContractNumber]
+		},
+	}))
+}
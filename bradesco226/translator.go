@@ -1,6 +1,21 @@
 package bradesco226
 
-import "github.com/libercapital/document-translator-go/internal/parser"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var (
+	ErrUnknownRegisterType = errors.New("unknown register type")
+	ErrMisplacedHeader     = errors.New("header must be the first and only header of the file")
+	ErrRecordQuantity      = errors.New("header record quantity does not match the file")
+)
 
 const kindPosition = 0
 
@@ -13,20 +28,35 @@ const (
 	RegisterTypeInstallment RegisterType = "4"
 )
 
-func Kind(line string) RegisterType {
-	kind := line[kindPosition : kindPosition+1]
+// Kind returns the register type of a line, from its first character.
+func Kind(line string) (RegisterType, error) {
+	if len(line) == 0 {
+		return RegisterType(""), errors.New("line with zero length")
+	}
+
+	kind := RegisterType(line[kindPosition : kindPosition+1])
 
 	switch kind {
-	case "1":
-		return RegisterTypeHeader
-	case "2":
-		return RegisterTypeContract
-	case "3":
-		return RegisterTypeBorrower
+	case RegisterTypeHeader, RegisterTypeContract, RegisterTypeBorrower, RegisterTypeInstallment:
+		return kind, nil
 	default:
-		return RegisterTypeInstallment
+		return RegisterType(""), wraperrors.NewErrWrap(ErrUnknownRegisterType, fmt.Errorf("register type %q", kind))
+	}
+}
+
+func ParseHeader(line string) (Header, error) {
+	data, err := parser.LineTo(
+		line,
+		func(line string) interface{} {
+			return new(Header)
+		},
+	)
 
+	if err != nil {
+		return Header{}, err
 	}
+
+	return data.(Header), nil
 }
 
 func ParseContract(line string) (Contract, error) {
@@ -73,3 +103,95 @@ func ParseInstallment(line string) (Installment, error) {
 
 	return data.(Installment), nil
 }
+
+// Parse parses a line as the record of its register type.
+func Parse(line string) (interface{}, error) {
+	kind, err := Kind(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case RegisterTypeHeader:
+		return ParseHeader(line)
+	case RegisterTypeContract:
+		return ParseContract(line)
+	case RegisterTypeBorrower:
+		return ParseBorrower(line)
+	default:
+		return ParseInstallment(line)
+	}
+}
+
+// ReadFile parses every line of a file, checking that it starts with its header and that
+// the header ContractQuantity, BorrowerQuantity and InstallmentQuantity match the records.
+//
+// Parameters:
+//   - r: The file contents. Blank lines and CRLF line endings are accepted.
+//
+// Returns:
+//   - []interface{}: The header, contracts, borrowers and installments, in line order.
+//   - error: The error of the first line not parsed, ErrMisplacedHeader for a header out of order, or ErrRecordQuantity.
+//
+// Example:
+//
+//	records, err := ReadFile(file)
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	header := records[0].(Header)
+func ReadFile(r io.Reader) (records []interface{}, err error) {
+	quantities := map[RegisterType]int{}
+
+	document, err := documenttranslator.ParseDocument(documenttranslator.FormatBradesco226, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document.Records) == 0 {
+		return nil, ErrMisplacedHeader
+	}
+
+	header, ok := document.Records[0].(Header)
+
+	if !ok {
+		return nil, wraperrors.NewErrWrap(ErrMisplacedHeader, fmt.Errorf("at record 1"))
+	}
+
+	for i, record := range document.Records {
+		switch record.(type) {
+		case Header:
+			if i != 0 {
+				return nil, wraperrors.NewErrWrap(ErrMisplacedHeader, fmt.Errorf("at record %d", i+1))
+			}
+		case Contract:
+			quantities[RegisterTypeContract]++
+		case Borrower:
+			quantities[RegisterTypeBorrower]++
+		case Installment:
+			quantities[RegisterTypeInstallment]++
+		}
+
+		records = append(records, record)
+	}
+
+	for _, quantity := range []struct {
+		field string
+		value string
+		kind  RegisterType
+	}{
+		{field: "ContractQuantity", value: header.ContractQuantity, kind: RegisterTypeContract},
+		{field: "BorrowerQuantity", value: header.BorrowerQuantity, kind: RegisterTypeBorrower},
+		{field: "InstallmentQuantity", value: header.InstallmentQuantity, kind: RegisterTypeInstallment},
+	} {
+		expected, err := strconv.Atoi(quantity.value)
+
+		if err != nil || expected != quantities[quantity.kind] {
+			return nil, wraperrors.NewErrWrap(ErrRecordQuantity, fmt.Errorf("header %s is %q and the file has %d", quantity.field, quantity.value, quantities[quantity.kind]))
+		}
+	}
+
+	return records, nil
+}
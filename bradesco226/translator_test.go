@@ -0,0 +1,104 @@
+package bradesco226
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	headerLine       = "10000123451601202400000000100000000100000000200012340                                                                                                                                                                             "
+	contractLine     = "2000054321             5299822470000251001202400000000001000000000000000000000000000000000000     002               0000000000000000000000000000000000                                 0000000000000000000000000000000000         "
+	borrowerLine     = "30000543211FULANO DE TAL                                                                                                                                                                                                          "
+	installmentLine1 = "40000543210011002202400000000000500000                                     0000000000000 0000000000000000000000000000000000                                                                                                       "
+	installmentLine2 = "40000543210021003202400000000000500000                                     0000000000000 0000000000000000000000000000000000                                                                                                       "
+)
+
+func TestKind(t *testing.T) {
+	kind, err := Kind(installmentLine1)
+	assert.NoError(t, err)
+	assert.Equal(t, RegisterTypeInstallment, kind)
+
+	_, err = Kind("X" + installmentLine1[1:])
+	assert.ErrorIs(t, err, ErrUnknownRegisterType)
+
+	_, err = Parse("X" + installmentLine1[1:])
+	assert.ErrorIs(t, err, ErrUnknownRegisterType)
+}
+
+func TestParseHeader(t *testing.T) {
+	header, err := ParseHeader(headerLine)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Header{
+		RegisterType:           "1",
+		ContractNumber:         "000012345",
+		MovementDate:           time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC),
+		ContractQuantity:       "000000001",
+		BorrowerQuantity:       "000000001",
+		InstallmentQuantity:    "000000002",
+		SourceCompanyCode:      "0001234",
+		RetroactiveAccountCode: "0",
+	}, header)
+}
+
+func TestReadFile(t *testing.T) {
+	lines := []string{headerLine, contractLine, borrowerLine, installmentLine1, installmentLine2}
+
+	records, err := ReadFile(strings.NewReader(strings.Join(lines, "\r\n") + "\r\n"))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+	assert.IsType(t, Header{}, records[0])
+	assert.IsType(t, Contract{}, records[1])
+	assert.IsType(t, Borrower{}, records[2])
+	assert.Equal(t, "002", records[4].(Installment).InstallmentNumber)
+}
+
+func TestReadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		wantErr error
+	}{
+		{
+			name:    "missing installment",
+			lines:   []string{headerLine, contractLine, borrowerLine, installmentLine1},
+			wantErr: ErrRecordQuantity,
+		},
+		{
+			name:    "extra contract",
+			lines:   []string{headerLine, contractLine, contractLine, borrowerLine, installmentLine1, installmentLine2},
+			wantErr: ErrRecordQuantity,
+		},
+		{
+			name:    "missing header",
+			lines:   []string{contractLine, borrowerLine, installmentLine1, installmentLine2},
+			wantErr: ErrMisplacedHeader,
+		},
+		{
+			name:    "second header",
+			lines:   []string{headerLine, contractLine, borrowerLine, headerLine, installmentLine1, installmentLine2},
+			wantErr: ErrMisplacedHeader,
+		},
+		{
+			name:    "empty file",
+			wantErr: ErrMisplacedHeader,
+		},
+		{
+			name:    "garbage line",
+			lines:   []string{headerLine, contractLine, borrowerLine, "X" + installmentLine1[1:], installmentLine2},
+			wantErr: ErrUnknownRegisterType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadFile(strings.NewReader(strings.Join(tt.lines, "\n")))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
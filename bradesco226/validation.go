@@ -0,0 +1,29 @@
+package bradesco226
+
+import (
+	"strings"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/checkdigit"
+)
+
+// BorrowerDocument joins the borrower document split across the contract columns, returning
+// the CPF (number and control) when the branch is zero and the CNPJ (root, branch and control) otherwise.
+func (c Contract) BorrowerDocument() string {
+	if strings.Trim(c.BorrowerFilial, "0 ") == "" {
+		return c.BorrowerDocumentNumber + c.BorrowerDocumentControl
+	}
+
+	return c.BorrowerDocumentNumber + c.BorrowerFilial + c.BorrowerDocumentControl
+}
+
+// ValidateRecord checks the check digits of the borrower document.
+func (c Contract) ValidateRecord() documenttranslator.ValidationErrors {
+	document := c.BorrowerDocument()
+
+	if strings.Trim(document, "0 ") == "" || checkdigit.ValidDocument(document) {
+		return nil
+	}
+
+	return documenttranslator.ValidationErrors{{Field: "BorrowerDocumentControl", Rule: "cpfcnpj", Value: document}}
+}
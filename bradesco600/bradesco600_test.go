@@ -1,21 +1,121 @@
 package bradesco600
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestCreditAssessment(t *testing.T) {
-	segment := "1501202400000000000117304ELETROZEMA S/A                          20264047310001961                   150120241402202400000000000000000000000083-REAL                0000000000011740400000000000000000000000000001174040000000000000000000000000000117404000000000001174040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000                                          0000124798515.01.2024001                                                                                                            "
+const assessmentLine = "1501202400000000000117304ELETROZEMA S/A                          20264047310001961                   150120241402202400000000000000000000000083-REAL                0000000000011740400000000000000000000000000001174040000000000000000000000000000117404000000000001174040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000                                          0000124798515.01.2024001                                                                                                     "
+
+func expectedAssessment() CreditAssessment {
+	zero := decimal.RequireFromString("0.00")
+	installment := decimal.RequireFromString("1174.04")
+
+	return CreditAssessment{
+		BaseDate:                    time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		ContractNumber:              "00000000000117304",
+		CustomerName:                "ELETROZEMA S/A",
+		PersonType:                  "2",
+		DocumentNumber:              "026404731000196",
+		AssessmentType:              "1",
+		ContractStartDate:           time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		ContractEndDate:             time.Date(2024, time.February, 14, 0, 0, 0, 0, time.UTC),
+		PaidInstallments:            0,
+		OverdueInstallments:         0,
+		QtyInstallments:             0,
+		AnualContractFee:            decimal.RequireFromString("0.0000000"),
+		Indexer:                     "083-REAL",
+		InstallmentPrice:            installment,
+		ContractPrice:               zero,
+		GuaranteePrice:              installment,
+		InitialContractPrice:        zero,
+		DuePrice:                    installment,
+		DuePriceBuckets:             [8]decimal.Decimal{installment, zero, zero, zero, zero, zero, zero, zero},
+		TotalOverDuePrice:           zero,
+		FirstOverDueInstallmentDate: time.Time{},
+		OverDuePriceBuckets:         [8]decimal.Decimal{zero, zero, zero, zero, zero, zero, zero, zero},
+		OperationRating:             "",
+		VehicleBrand:                "",
+		VehicleModel:                "",
+		VehicleYear:                 0,
+		SystemSource:                1247985,
+		AquisitionDate:              time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		AquisitionCode:              1,
+		Return:                      AssessmentReturn{},
+	}
+}
+
+func TestCreditAssessmentParse(t *testing.T) {
+	parsed, err := Parse(assessmentLine)
+
+	assert.NoError(t, err)
+	assert.Len(t, assessmentLine, 713)
+	assert.Equal(t, expectedAssessment(), parsed)
+	assert.False(t, parsed.Return.Returned())
+}
+
+func TestCreditAssessmentWrite(t *testing.T) {
+	line, err := expectedAssessment().String()
+
+	assert.NoError(t, err)
+	assert.Equal(t, assessmentLine, line)
+}
+
+func TestCreditAssessmentWithoutAquisitionDate(t *testing.T) {
+	assessment := expectedAssessment()
+	assessment.AquisitionDate = time.Time{}
+
+	line, err := assessment.String()
+
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat(" ", 10), line[599:609])
+
+	parsed, err := Parse(line)
+
+	assert.NoError(t, err)
+	assert.Equal(t, assessment, parsed)
+}
 
-	parsed, err := Parse(segment)
-	if err != nil {
-		assert.Fail(t, err.Error())
+func TestCreditAssessmentReturn(t *testing.T) {
+	tests := []struct {
+		name     string
+		area     string
+		expected AssessmentReturn
+		returned bool
+	}{
+		{
+			name:     "returned",
+			area:     "000OPERACAO PROCESSADA",
+			expected: AssessmentReturn{Text: "000OPERACAO PROCESSADA"},
+			returned: true,
+		},
+		{
+			name:     "blank",
+			area:     "",
+			expected: AssessmentReturn{},
+			returned: false,
+		},
 	}
 
-	// TODO: test is incomplete. Fields don't match perfectly.
-	assert.Equal(t, "15012024", parsed.BaseDate.Format("02012006"))
-	assert.Equal(t, "ELETROZEMA S/A", parsed.CustomerName)
-	assert.Equal(t, "083-REAL", parsed.Indexer)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			line := assessmentLine[:612] + test.area + strings.Repeat(" ", 101-len(test.area))
+
+			parsed, err := Parse(line)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, parsed.Return)
+			assert.Equal(t, test.returned, parsed.Return.Returned())
+
+			written, err := parsed.String()
+
+			assert.NoError(t, err)
+			assert.Equal(t, line, written)
+		})
+	}
 }
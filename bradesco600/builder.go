@@ -0,0 +1,176 @@
+package bradesco600
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/libercapital/document-translator-go/bradesco226"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var ErrContractWithoutInstallments = errors.New("contract without installments")
+
+// overdueGraceDays is how long an installment may be late and still be reported as to fall due.
+const overdueGraceDays = 14
+
+// bucketLimits holds the last day of each aging bucket but the last, which has no limit.
+var bucketLimits = [7]int{30, 60, 90, 120, 150, 180, 360}
+
+// PortfolioInstallment is an installment of a loan portfolio contract.
+type PortfolioInstallment struct {
+	DueDate time.Time
+	Amount  decimal.Decimal
+	PaidAt  time.Time // PaidAt is the payment date, zero while the installment is unpaid.
+}
+
+// PortfolioContract is a loan portfolio contract and its installments.
+type PortfolioContract struct {
+	// Assessment holds the fields not derived from the installments, such as ContractNumber,
+	// CustomerName, DocumentNumber, AnualContractFee or the vehicle fields, copied to the line.
+	Assessment   CreditAssessment
+	Installments []PortfolioInstallment
+}
+
+// BuildCreditAssessment computes the installment counters and aging buckets of a contract at
+// a reference date. Unpaid installments are reported as to fall due, bucketed by the days until
+// their due date, unless they are more than 14 days late, when they are reported as overdue,
+// bucketed by the days since their due date. Installments paid after baseDate count as unpaid.
+//
+// Parameters:
+//   - contract: The contract and its installments.
+//   - baseDate: The reference date of the assessment.
+//
+// Returns:
+//   - CreditAssessment: The assessment, whose String method writes its 713 bytes line.
+//   - error: ErrContractWithoutInstallments when the contract has no installments.
+//
+// Example:
+//
+//	assessment, err := BuildCreditAssessment(contract, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC))
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	line, err := assessment.String()
+func BuildCreditAssessment(contract PortfolioContract, baseDate time.Time) (CreditAssessment, error) {
+	assessment := contract.Assessment
+
+	if len(contract.Installments) == 0 {
+		return CreditAssessment{}, wraperrors.NewErrWrap(ErrContractWithoutInstallments, fmt.Errorf("at contract %s", assessment.ContractNumber))
+	}
+
+	for i := range assessment.DuePriceBuckets {
+		assessment.DuePriceBuckets[i], assessment.OverDuePriceBuckets[i] = decimal.Zero, decimal.Zero
+	}
+
+	assessment.BaseDate = baseDate
+	assessment.QtyInstallments = int64(len(contract.Installments))
+	assessment.PaidInstallments, assessment.OverdueInstallments = 0, 0
+	assessment.DuePrice, assessment.TotalOverDuePrice = decimal.Zero, decimal.Zero
+	assessment.InstallmentPrice = decimal.Zero
+	assessment.ContractEndDate = time.Time{}
+	assessment.FirstOverDueInstallmentDate = time.Time{}
+
+	for _, installment := range contract.Installments {
+		if installment.DueDate.After(assessment.ContractEndDate) {
+			assessment.ContractEndDate = installment.DueDate
+		}
+
+		if !installment.PaidAt.IsZero() && daysBetween(baseDate, installment.PaidAt) <= 0 {
+			assessment.PaidInstallments++
+			continue
+		}
+
+		days := daysBetween(baseDate, installment.DueDate)
+
+		if assessment.InstallmentPrice.IsZero() {
+			assessment.InstallmentPrice = installment.Amount
+		}
+
+		if days >= -overdueGraceDays {
+			bucket := agingBucket(days)
+			assessment.DuePriceBuckets[bucket] = assessment.DuePriceBuckets[bucket].Add(installment.Amount)
+			assessment.DuePrice = assessment.DuePrice.Add(installment.Amount)
+			continue
+		}
+
+		bucket := agingBucket(-days)
+		assessment.OverDuePriceBuckets[bucket] = assessment.OverDuePriceBuckets[bucket].Add(installment.Amount)
+		assessment.TotalOverDuePrice = assessment.TotalOverDuePrice.Add(installment.Amount)
+		assessment.OverdueInstallments++
+
+		if assessment.FirstOverDueInstallmentDate.IsZero() || installment.DueDate.Before(assessment.FirstOverDueInstallmentDate) {
+			assessment.FirstOverDueInstallmentDate = installment.DueDate
+		}
+	}
+
+	if assessment.InstallmentPrice.IsZero() {
+		assessment.InstallmentPrice = contract.Installments[len(contract.Installments)-1].Amount
+	}
+
+	return assessment, nil
+}
+
+// NewPortfolioContract builds a portfolio contract from a bradesco226 contract, identifying
+// the customer by its first borrower.
+//
+// Parameters:
+//   - contract: The contract, as grouped by bradesco226.GroupContracts.
+//   - paidAt: Returns the payment date of an installment, or a zero time while it is unpaid.
+func NewPortfolioContract(contract bradesco226.ContractAggregate, paidAt func(installment bradesco226.Installment) time.Time) PortfolioContract {
+	portfolio := PortfolioContract{
+		Assessment: CreditAssessment{
+			ContractNumber:    zeroPadded(contract.Contract.ContractNumber, 17),
+			DocumentNumber:    zeroPadded(contract.Contract.BorrowerDocument(), 15),
+			ContractStartDate: contract.Contract.Date,
+			ContractPrice:     contract.Contract.ContractValue,
+			GuaranteePrice:    contract.Contract.ProductValue,
+		},
+	}
+
+	if len(contract.Borrowers) > 0 {
+		portfolio.Assessment.CustomerName = contract.Borrowers[0].Name
+		portfolio.Assessment.PersonType = contract.Borrowers[0].PersonType
+	}
+
+	for _, installment := range contract.Installments {
+		portfolio.Installments = append(portfolio.Installments, PortfolioInstallment{
+			DueDate: installment.DueDate,
+			Amount:  installment.Amount,
+			PaidAt:  paidAt(installment),
+		})
+	}
+
+	return portfolio
+}
+
+// agingBucket returns the index of the aging bucket of a number of days.
+func agingBucket(days int) int {
+	for i, limit := range bucketLimits {
+		if days <= limit {
+			return i
+		}
+	}
+
+	return len(bucketLimits)
+}
+
+// daysBetween returns the calendar days from one date to another, negative when to is earlier.
+func daysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+func zeroPadded(value string, width int) string {
+	value = strings.TrimSpace(value)
+
+	if len(value) >= width {
+		return value
+	}
+
+	return strings.Repeat("0", width-len(value)) + value
+}
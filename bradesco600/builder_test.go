@@ -0,0 +1,113 @@
+package bradesco600
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libercapital/document-translator-go/bradesco226"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreditAssessment(t *testing.T) {
+	baseDate := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	amount := decimal.RequireFromString("100.00")
+
+	contract := PortfolioContract{
+		Assessment: CreditAssessment{
+			ContractNumber: "00000000000054321",
+			CustomerName:   "FULANO DE TAL",
+			PersonType:     "1",
+			DocumentNumber: "000052998224725",
+			Indexer:        "083-REAL",
+		},
+		Installments: []PortfolioInstallment{
+			{DueDate: date(2023, time.May, 30), Amount: amount},                                      // 397 days late
+			{DueDate: date(2024, time.March, 30), Amount: amount, PaidAt: date(2024, time.April, 2)}, // paid
+			{DueDate: date(2024, time.April, 30), Amount: amount},                                    // 61 days late
+			{DueDate: date(2024, time.May, 30), Amount: amount, PaidAt: date(2024, time.July, 1)},    // 31 days late, paid after the base date
+			{DueDate: date(2024, time.June, 20), Amount: amount},                                     // 10 days late, still to fall due
+			{DueDate: date(2024, time.July, 30), Amount: amount},                                     // due in 30 days
+			{DueDate: date(2024, time.August, 30), Amount: amount},                                   // due in 61 days
+			{DueDate: date(2025, time.July, 30), Amount: amount},                                     // due in 395 days
+		},
+	}
+
+	assessment, err := BuildCreditAssessment(contract, baseDate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, baseDate, assessment.BaseDate)
+	assert.Equal(t, date(2025, time.July, 30), assessment.ContractEndDate)
+	assert.Equal(t, int64(8), assessment.QtyInstallments)
+	assert.Equal(t, int64(1), assessment.PaidInstallments)
+	assert.Equal(t, int64(3), assessment.OverdueInstallments)
+	assert.Equal(t, date(2023, time.May, 30), assessment.FirstOverDueInstallmentDate)
+	assert.Equal(t, "100.00", assessment.InstallmentPrice.StringFixed(2))
+	assert.Equal(t, "400.00", assessment.DuePrice.StringFixed(2))
+	assert.Equal(t, "300.00", assessment.TotalOverDuePrice.StringFixed(2))
+
+	var due, overdue []string
+
+	for i := range assessment.DuePriceBuckets {
+		due = append(due, assessment.DuePriceBuckets[i].StringFixed(2))
+		overdue = append(overdue, assessment.OverDuePriceBuckets[i].StringFixed(2))
+	}
+
+	assert.Equal(t, []string{"200.00", "0.00", "100.00", "0.00", "0.00", "0.00", "0.00", "100.00"}, due)
+	assert.Equal(t, []string{"0.00", "100.00", "100.00", "0.00", "0.00", "0.00", "0.00", "100.00"}, overdue)
+
+	line, err := assessment.String()
+
+	assert.NoError(t, err)
+	assert.Len(t, line, 713)
+
+	parsed, err := Parse(line)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), parsed.OverdueInstallments)
+	assert.True(t, assessment.TotalOverDuePrice.Equal(parsed.TotalOverDuePrice))
+}
+
+func TestBuildCreditAssessmentWithoutInstallments(t *testing.T) {
+	_, err := BuildCreditAssessment(PortfolioContract{}, time.Now())
+
+	assert.ErrorIs(t, err, ErrContractWithoutInstallments)
+}
+
+func TestNewPortfolioContract(t *testing.T) {
+	paidDate := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	contract := bradesco226.ContractAggregate{
+		Contract: bradesco226.Contract{
+			ContractNumber:          "000054321",
+			BorrowerDocumentNumber:  "529982247",
+			BorrowerFilial:          "0000",
+			BorrowerDocumentControl: "25",
+			Date:                    time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+			ContractValue:           decimal.RequireFromString("10000"),
+		},
+		Borrowers: []bradesco226.Borrower{{ContractNumber: "000054321", PersonType: "1", Name: "FULANO DE TAL"}},
+		Installments: []bradesco226.Installment{
+			{ContractNumber: "000054321", InstallmentNumber: "001", DueDate: paidDate, Amount: decimal.RequireFromString("5000")},
+			{ContractNumber: "000054321", InstallmentNumber: "002", DueDate: paidDate.AddDate(0, 1, 0), Amount: decimal.RequireFromString("5000")},
+		},
+	}
+
+	portfolio := NewPortfolioContract(contract, func(installment bradesco226.Installment) time.Time {
+		if installment.InstallmentNumber == "001" {
+			return paidDate
+		}
+
+		return time.Time{}
+	})
+
+	assert.Equal(t, "00000000000054321", portfolio.Assessment.ContractNumber)
+	assert.Equal(t, "000052998224725", portfolio.Assessment.DocumentNumber)
+	assert.Equal(t, "FULANO DE TAL", portfolio.Assessment.CustomerName)
+	assert.Equal(t, "1", portfolio.Assessment.PersonType)
+	assert.Len(t, portfolio.Installments, 2)
+	assert.Equal(t, paidDate, portfolio.Installments[0].PaidAt)
+	assert.True(t, portfolio.Installments[1].PaidAt.IsZero())
+}
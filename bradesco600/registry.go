@@ -0,0 +1,24 @@
+package bradesco600
+
+import (
+	documenttranslator "github.com/libercapital/document-translator-go"
+)
+
+// kind is the registry key of the only record type of the format.
+const kind = "credit-assessment"
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBradesco600,
+		RecordLength: 713,
+		Kind: func(line string) (string, error) {
+			return kind, nil
+		},
+		Records: map[string]documenttranslator.Record{
+			kind: CreditAssessment{},
+		},
+		Parse: func(line string) (documenttranslator.Record, error) {
+			return Parse(line)
+		},
+	})
+}
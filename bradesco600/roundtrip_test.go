@@ -0,0 +1,11 @@
+package bradesco600
+
+import (
+	"testing"
+
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestRoundTripRandomRecords(t *testing.T) {
+	roundtrip.Check(t, CreditAssessment{}, 200, nil)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type ContractSettlementHeader struct {
-	TipoRegistro  int       `translator:"part:0..0"`
+	TipoRegistro  int       `translator:"part:0..0;kind:0"`
 	DataMovimento time.Time `translator:"part:1..8;timeParse:02012006"`
 	Nome          string    `translator:"part:9..48"`
 	EmpresaOrigem int       `translator:"part:49..55"`
@@ -16,11 +16,11 @@ type ContractSettlementHeader struct {
 }
 
 func (c ContractSettlementHeader) String() (string, error) {
-	return writer.Marshal(c, 80)
+	return writer.MarshalKeepCase(c, 80)
 }
 
 type ContractSettlementRegister struct {
-	TipoRegistro                    string          `translator:"part:0..0"`
+	TipoRegistro                    string          `translator:"part:0..0;kind:1"`
 	SistemaOrigem                   string          `translator:"part:1..3"`
 	CodigoConvenio                  string          `translator:"part:4..12"`
 	ContratoOrigem                  string          `translator:"part:13..21"`
@@ -37,15 +37,15 @@ type ContractSettlementRegister struct {
 }
 
 func (c ContractSettlementRegister) String() (string, error) {
-	return writer.Marshal(c, 80)
+	return writer.MarshalKeepCase(c, 80)
 }
 
 type ContractSettlementTrailer struct {
-	TipoRegistro        int    `translator:"part:0..0"`
+	TipoRegistro        int    `translator:"part:0..0;kind:9"`
 	QuantidadeRegistros int    `translator:"part:1..6"`
 	Filler              string `translator:"part:7..79"`
 }
 
 func (c ContractSettlementTrailer) String() (string, error) {
-	return writer.Marshal(c, 80)
+	return writer.MarshalKeepCase(c, 80)
 }
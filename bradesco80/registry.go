@@ -0,0 +1,64 @@
+package bradesco80
+
+import (
+	"fmt"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBradesco80,
+		RecordLength: 80,
+		Kind: func(line string) (string, error) {
+			kind, err := Kind(line)
+
+			return string(kind), err
+		},
+		Records: map[string]documenttranslator.Record{
+			string(RegisterTypeHeader):   ContractSettlementHeader{},
+			string(RegisterTypeRegister): ContractSettlementRegister{},
+			string(RegisterTypeTrailer):  ContractSettlementTrailer{},
+		},
+		Parse: func(line string) (documenttranslator.Record, error) {
+			record, err := Parse(line)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return record.(documenttranslator.Record), nil
+		},
+		Finalize: finalize,
+	})
+}
+
+// finalize completes an imported settlement file with its trailer, counting every register
+// of the file, header and trailer included. A trailer ending the records is kept with its
+// count recomputed.
+func finalize(records []documenttranslator.Record) ([]documenttranslator.Record, error) {
+	if len(records) == 0 {
+		return nil, ErrMisplacedRegister
+	}
+
+	trailer := ContractSettlementTrailer{TipoRegistro: 9}
+
+	if last, ok := records[len(records)-1].(ContractSettlementTrailer); ok {
+		trailer = last
+		records = records[:len(records)-1]
+	}
+
+	for i, record := range records {
+		_, isHeader := record.(ContractSettlementHeader)
+		_, isTrailer := record.(ContractSettlementTrailer)
+
+		if isTrailer || isHeader != (i == 0) {
+			return nil, wraperrors.NewErrWrap(ErrMisplacedRegister, fmt.Errorf("at record %d", i+1))
+		}
+	}
+
+	trailer.QuantidadeRegistros = len(records) + 1
+
+	return append(records, trailer), nil
+}
@@ -0,0 +1,13 @@
+package bradesco80
+
+import (
+	"testing"
+
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestRoundTripRandomRecords(t *testing.T) {
+	roundtrip.Check(t, ContractSettlementHeader{}, 200, nil)
+	roundtrip.Check(t, ContractSettlementRegister{}, 200, nil)
+	roundtrip.Check(t, ContractSettlementTrailer{}, 200, nil)
+}
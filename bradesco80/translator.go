@@ -0,0 +1,159 @@
+package bradesco80
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var (
+	ErrUnknownRegisterType = errors.New("unknown register type")
+	ErrMisplacedRegister   = errors.New("register out of the header, registers and trailer order")
+	ErrMissingTrailer      = errors.New("file without trailer")
+	ErrRegisterQuantity    = errors.New("trailer register quantity does not match the file")
+)
+
+const kindPosition = 0
+
+type RegisterType string
+
+const (
+	RegisterTypeHeader   RegisterType = "0"
+	RegisterTypeRegister RegisterType = "1"
+	RegisterTypeTrailer  RegisterType = "9"
+)
+
+// Kind returns the register type of a line, from its TipoRegistro column.
+func Kind(line string) (RegisterType, error) {
+	if len(line) == 0 {
+		return RegisterType(""), errors.New("line with zero length")
+	}
+
+	kind := RegisterType(line[kindPosition : kindPosition+1])
+
+	switch kind {
+	case RegisterTypeHeader, RegisterTypeRegister, RegisterTypeTrailer:
+		return kind, nil
+	default:
+		return RegisterType(""), wraperrors.NewErrWrap(ErrUnknownRegisterType, fmt.Errorf("register type %q", kind))
+	}
+}
+
+func ParseHeader(line string) (ContractSettlementHeader, error) {
+	data, err := parser.LineTo(
+		line,
+		func(line string) interface{} {
+			return new(ContractSettlementHeader)
+		},
+	)
+
+	if err != nil {
+		return ContractSettlementHeader{}, err
+	}
+
+	return data.(ContractSettlementHeader), nil
+}
+
+func ParseRegister(line string) (ContractSettlementRegister, error) {
+	data, err := parser.LineTo(
+		line,
+		func(line string) interface{} {
+			return new(ContractSettlementRegister)
+		},
+	)
+
+	if err != nil {
+		return ContractSettlementRegister{}, err
+	}
+
+	return data.(ContractSettlementRegister), nil
+}
+
+func ParseTrailer(line string) (ContractSettlementTrailer, error) {
+	data, err := parser.LineTo(
+		line,
+		func(line string) interface{} {
+			return new(ContractSettlementTrailer)
+		},
+	)
+
+	if err != nil {
+		return ContractSettlementTrailer{}, err
+	}
+
+	return data.(ContractSettlementTrailer), nil
+}
+
+// Parse parses a line as the record of its register type.
+func Parse(line string) (interface{}, error) {
+	kind, err := Kind(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case RegisterTypeHeader:
+		return ParseHeader(line)
+	case RegisterTypeRegister:
+		return ParseRegister(line)
+	default:
+		return ParseTrailer(line)
+	}
+}
+
+// ReadFile parses every line of a settlement file, checking that it starts with a header,
+// ends with a trailer and that the trailer QuantidadeRegistros counts every line of the
+// file, header and trailer included.
+//
+// Parameters:
+//   - r: The file contents. Blank lines and CRLF line endings are accepted.
+//
+// Returns:
+//   - []interface{}: The header, the registers and the trailer, in line order.
+//   - error: The error of the first line not parsed, ErrMisplacedRegister for a register out of order, or ErrRegisterQuantity.
+//
+// Example:
+//
+//	records, err := ReadFile(file)
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	header := records[0].(ContractSettlementHeader)
+func ReadFile(r io.Reader) (records []interface{}, err error) {
+	var trailer *ContractSettlementTrailer
+
+	document, err := documenttranslator.ParseDocument(documenttranslator.FormatBradesco80, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range document.Records {
+		_, isHeader := record.(ContractSettlementHeader)
+
+		if trailer != nil || isHeader != (i == 0) {
+			return nil, wraperrors.NewErrWrap(ErrMisplacedRegister, fmt.Errorf("at record %d", i+1))
+		}
+
+		if parsed, ok := record.(ContractSettlementTrailer); ok {
+			trailer = &parsed
+		}
+
+		records = append(records, record)
+	}
+
+	if trailer == nil {
+		return nil, ErrMissingTrailer
+	}
+
+	if trailer.QuantidadeRegistros != len(records) {
+		return nil, wraperrors.NewErrWrap(ErrRegisterQuantity, fmt.Errorf("trailer counts %d registers and the file has %d", trailer.QuantidadeRegistros, len(records)))
+	}
+
+	return records, nil
+}
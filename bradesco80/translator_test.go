@@ -0,0 +1,108 @@
+package bradesco80
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	headerLine   = "016012024BANCO BRADESCO S.A.                     0001234                        "
+	registerLine = "1BDN000012345000098765L15022024010100005432100000000000150025003S              L"
+	trailerLine  = "9000003                                                                         "
+)
+
+func TestKind(t *testing.T) {
+	kind, err := Kind(registerLine)
+	assert.NoError(t, err)
+	assert.Equal(t, RegisterTypeRegister, kind)
+
+	_, err = Kind("5" + registerLine[1:])
+	assert.ErrorIs(t, err, ErrUnknownRegisterType)
+}
+
+func TestParse(t *testing.T) {
+	header, err := Parse(headerLine)
+	assert.NoError(t, err)
+	assert.Equal(t, ContractSettlementHeader{
+		TipoRegistro:  0,
+		DataMovimento: time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC),
+		Nome:          "BANCO BRADESCO S.A.",
+		EmpresaOrigem: 1234,
+	}, header)
+
+	register, err := Parse(registerLine)
+	assert.NoError(t, err)
+	assert.Equal(t, ContractSettlementRegister{
+		TipoRegistro:                    "1",
+		SistemaOrigem:                   "BDN",
+		CodigoConvenio:                  "000012345",
+		ContratoOrigem:                  "000098765",
+		TipoPagamento:                   "L",
+		DataVencimentoParcela:           time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC),
+		Produto:                         "010",
+		Familia:                         "1",
+		Contrato:                        "000054321",
+		ValorPagameto:                   decimal.RequireFromString("1500.25"),
+		NumeroParcela:                   "003",
+		ADebitarNaConta:                 "S",
+		IdentificadorRecompraLiquidacao: "L",
+	}, register)
+
+	trailer, err := Parse(trailerLine)
+	assert.NoError(t, err)
+	assert.Equal(t, ContractSettlementTrailer{TipoRegistro: 9, QuantidadeRegistros: 3}, trailer)
+}
+
+func TestReadFile(t *testing.T) {
+	records, err := ReadFile(strings.NewReader(strings.Join([]string{headerLine, registerLine, trailerLine}, "\r\n") + "\r\n"))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.IsType(t, ContractSettlementRegister{}, records[1])
+}
+
+func TestReadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		wantErr error
+	}{
+		{
+			name:    "trailer quantity mismatch",
+			lines:   []string{headerLine, registerLine, registerLine, trailerLine},
+			wantErr: ErrRegisterQuantity,
+		},
+		{
+			name:    "missing trailer",
+			lines:   []string{headerLine, registerLine},
+			wantErr: ErrMissingTrailer,
+		},
+		{
+			name:    "missing header",
+			lines:   []string{registerLine, trailerLine},
+			wantErr: ErrMisplacedRegister,
+		},
+		{
+			name:    "register after the trailer",
+			lines:   []string{headerLine, trailerLine, registerLine},
+			wantErr: ErrMisplacedRegister,
+		},
+		{
+			name:    "unknown register type",
+			lines:   []string{headerLine, "5" + registerLine[1:], trailerLine},
+			wantErr: ErrUnknownRegisterType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadFile(strings.NewReader(strings.Join(tt.lines, "\n")))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
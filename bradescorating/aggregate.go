@@ -0,0 +1,88 @@
+package bradescorating
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var ErrNegativeAmount = errors.New("rating amount is negative")
+
+// documentWidth is the width of the DocumentNumber column.
+const documentWidth = 15
+
+// Exposure is an amount owed by a borrower, identified by its CPF or CNPJ.
+type Exposure struct {
+	DocumentNumber string // DocumentNumber is the CPF or CNPJ, with or without punctuation.
+	Amount         decimal.Decimal
+}
+
+// Aggregate sums the exposures of each document into the ratings of a submission.
+//
+// Parameters:
+//   - exposures: The exposures, in any order. Documents are compared without punctuation
+//     and padded with zeros to the 15 digits of the DocumentNumber column.
+//
+// Returns:
+//   - []Rating: One rating per document, in the order each document first appears.
+//   - error: A validation error for an invalid CPF or CNPJ, or ErrNegativeAmount, with the document number.
+//
+// Example:
+//
+//	ratings, err := Aggregate(exposures)
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	err = WriteFile(file, ratings)
+func Aggregate(exposures []Exposure) ([]Rating, error) {
+	var ratings []Rating
+
+	indexes := map[string]int{}
+
+	for _, exposure := range exposures {
+		document := normalizeDocument(exposure.DocumentNumber)
+
+		index, ok := indexes[document]
+
+		if !ok {
+			index = len(ratings)
+			indexes[document] = index
+			ratings = append(ratings, Rating{DocumentNumber: document, Amount: decimal.Zero})
+		}
+
+		ratings[index].Amount = ratings[index].Amount.Add(exposure.Amount)
+	}
+
+	for _, rating := range ratings {
+		if rating.Amount.IsNegative() {
+			return nil, wraperrors.NewErrWrap(ErrNegativeAmount, fmt.Errorf("at document %s", rating.DocumentNumber))
+		}
+
+		if _, err := rating.String(); err != nil {
+			return nil, wraperrors.NewErrWrap(err, fmt.Errorf("at document %s", rating.DocumentNumber))
+		}
+	}
+
+	return ratings, nil
+}
+
+// normalizeDocument strips the punctuation of a CPF or CNPJ and pads it with zeros to the
+// width of the DocumentNumber column.
+func normalizeDocument(document string) string {
+	document = strings.ToUpper(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(".-/ ", r) {
+			return -1
+		}
+
+		return r
+	}, document))
+
+	if len(document) >= documentWidth {
+		return document
+	}
+
+	return strings.Repeat("0", documentWidth-len(document)) + document
+}
@@ -0,0 +1,58 @@
+package bradescorating
+
+import (
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	ratings, err := Aggregate([]Exposure{
+		{DocumentNumber: "529.982.247-25", Amount: decimal.RequireFromString("1000.25")},
+		{DocumentNumber: "26.404.731/0001-96", Amount: decimal.RequireFromString("10000")},
+		{DocumentNumber: "52998224725", Amount: decimal.RequireFromString("500")},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, ratings, 2)
+	assert.Equal(t, "000052998224725", ratings[0].DocumentNumber)
+	assert.True(t, decimal.RequireFromString("1500.25").Equal(ratings[0].Amount))
+	assert.Equal(t, "026404731000196", ratings[1].DocumentNumber)
+	assert.True(t, decimal.RequireFromString("10000").Equal(ratings[1].Amount))
+
+	line, err := ratings[0].String()
+	assert.NoError(t, err)
+	assert.Equal(t, cpfLine, line)
+}
+
+func TestAggregateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		exposures []Exposure
+		wantErr   error
+	}{
+		{
+			name:      "invalid document",
+			exposures: []Exposure{{DocumentNumber: "529.982.247-26", Amount: decimal.NewFromInt(1)}},
+			wantErr:   documenttranslator.ErrValidation,
+		},
+		{
+			name: "negative amount",
+			exposures: []Exposure{
+				{DocumentNumber: "52998224725", Amount: decimal.NewFromInt(1)},
+				{DocumentNumber: "52998224725", Amount: decimal.NewFromInt(-2)},
+			},
+			wantErr: ErrNegativeAmount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Aggregate(test.exposures)
+
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
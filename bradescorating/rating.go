@@ -6,10 +6,10 @@ import (
 )
 
 type Rating struct {
-	DocumentNumber string          `translator:"part:0..14"`
-	Amount         decimal.Decimal `translator:"part:15..29;precision:2"`
+	DocumentNumber string          `translator:"part:0..14" validate:"required;cpfcnpj" spec:"cpf_cnpj"`
+	Amount         decimal.Decimal `translator:"part:15..29;precision:2" spec:"valor"`
 }
 
 func (c Rating) String() (string, error) {
-	return writer.Marshal(c, 30)
+	return writer.MarshalKeepCase(c, 30)
 }
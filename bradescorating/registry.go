@@ -0,0 +1,24 @@
+package bradescorating
+
+import (
+	documenttranslator "github.com/libercapital/document-translator-go"
+)
+
+// kind is the registry key of the only record type of the format.
+const kind = "rating"
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBradescoRating,
+		RecordLength: 30,
+		Kind: func(line string) (string, error) {
+			return kind, nil
+		},
+		Records: map[string]documenttranslator.Record{
+			kind: Rating{},
+		},
+		Parse: func(line string) (documenttranslator.Record, error) {
+			return Parse(line)
+		},
+	})
+}
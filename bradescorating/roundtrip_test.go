@@ -0,0 +1,11 @@
+package bradescorating
+
+import (
+	"testing"
+
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestRoundTripRandomRecords(t *testing.T) {
+	roundtrip.Check(t, Rating{}, 200, nil)
+}
@@ -1,12 +1,101 @@
 package bradescorating
 
-import "github.com/libercapital/document-translator-go/internal/parser"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
-func Parse(line string) (interface{}, error) {
-	return parser.LineTo(
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var ErrDuplicateDocument = errors.New("document number appears in more than one rating")
+
+func Parse(line string) (Rating, error) {
+	data, err := parser.LineTo(
 		line,
 		func(line string) interface{} {
 			return new(Rating)
 		},
 	)
+
+	if err != nil {
+		return Rating{}, err
+	}
+
+	return data.(Rating), nil
+}
+
+// ReadFile parses every line of a rating file, checking that no document number is rated twice.
+//
+// Parameters:
+//   - r: The file contents. Blank lines and CRLF line endings are accepted.
+//
+// Returns:
+//   - []Rating: The ratings, in line order.
+//   - error: The error of the first line not parsed, or ErrDuplicateDocument with the positions of both ratings.
+//
+// Example:
+//
+//	ratings, err := ReadFile(file)
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ReadFile(r io.Reader) (ratings []Rating, err error) {
+	positions := map[string]int{}
+
+	document, err := documenttranslator.ParseDocument(documenttranslator.FormatBradescoRating, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range document.Records {
+		rating := record.(Rating)
+		number := normalizeDocument(rating.DocumentNumber)
+
+		if first, ok := positions[number]; ok {
+			return nil, wraperrors.NewErrWrap(ErrDuplicateDocument, fmt.Errorf("at rating %d, already rated at rating %d", i+1, first))
+		}
+
+		positions[number] = i + 1
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, nil
+}
+
+// WriteFile writes the ratings with lines ended by CRLF, checking that no document number
+// is rated twice. Nothing is written when a rating is rejected.
+//
+// Returns:
+//   - error: ErrDuplicateDocument, the error of the first rating not written or the error of w.
+func WriteFile(w io.Writer, ratings []Rating) error {
+	var file strings.Builder
+
+	positions := map[string]int{}
+
+	for i, rating := range ratings {
+		document := normalizeDocument(rating.DocumentNumber)
+
+		if first, ok := positions[document]; ok {
+			return wraperrors.NewErrWrap(ErrDuplicateDocument, fmt.Errorf("at rating %d, already rated at rating %d", i+1, first))
+		}
+
+		positions[document] = i + 1
+
+		line, err := rating.String()
+
+		if err != nil {
+			return wraperrors.NewErrWrap(err, fmt.Errorf("at rating %d", i+1))
+		}
+
+		file.WriteString(line + "\r\n")
+	}
+
+	_, err := io.WriteString(w, file.String())
+
+	return err
 }
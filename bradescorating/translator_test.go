@@ -0,0 +1,85 @@
+package bradescorating
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cpfLine  = "000052998224725000000000150025"
+	cnpjLine = "026404731000196000000001000000"
+)
+
+func TestParse(t *testing.T) {
+	rating, err := Parse(cpfLine)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "000052998224725", rating.DocumentNumber)
+	assert.True(t, decimal.RequireFromString("1500.25").Equal(rating.Amount))
+
+	_, err = Parse("000052998224726000000000150025")
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+
+	_, err = Parse("               000000000150025")
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    int
+		wantErr error
+	}{
+		{
+			name: "ratings",
+			file: cpfLine + "\r\n" + cnpjLine + "\r\n",
+			want: 2,
+		},
+		{
+			name:    "duplicate document",
+			file:    strings.Join([]string{cpfLine, cnpjLine, "000052998224725000000000000100"}, "\n"),
+			wantErr: ErrDuplicateDocument,
+		},
+		{
+			name:    "invalid document",
+			file:    cpfLine + "\n" + "026404731000197000000001000000",
+			wantErr: documenttranslator.ErrValidation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ratings, err := ReadFile(strings.NewReader(test.file))
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, ratings, test.want)
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	ratings, err := ReadFile(strings.NewReader(cpfLine + "\n" + cnpjLine))
+	assert.NoError(t, err)
+
+	var file bytes.Buffer
+
+	assert.NoError(t, WriteFile(&file, ratings))
+	assert.Equal(t, cpfLine+"\r\n"+cnpjLine+"\r\n", file.String())
+
+	file.Reset()
+
+	err = WriteFile(&file, append(ratings, Rating{DocumentNumber: "52998224725", Amount: decimal.NewFromInt(1)}))
+	assert.ErrorIs(t, err, ErrDuplicateDocument)
+	assert.Empty(t, file.String())
+}
@@ -0,0 +1,389 @@
+package brf240
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrRemittanceWithoutHeader = errors.New("remittance record found before its file or batch header")
+	ErrMissingOutcome          = errors.New("remittance payment without outcome")
+	ErrUnknownOutcome          = errors.New("outcome does not match any remittance payment")
+	ErrDuplicateOutcome        = errors.New("outcome informed more than once for the same remittance payment")
+	ErrTooManyOccurrenceCodes  = errors.New("outcome has more occurrence codes than the occurrence column holds")
+	ErrInvalidOccurrenceCode   = errors.New("outcome occurrence code is not a catalogued two-character code")
+	ErrNonNumericColumn        = errors.New("remittance column copied to a numeric return column is not a number")
+	ErrUnreturnableRecord      = errors.New("remittance record has no counterpart in the return layout")
+)
+
+const maxOccurrenceCodes = 5
+
+// BillingOutcome is the result of a remittance payment, matched by its batch and sequential numbers.
+type BillingOutcome struct {
+	BatchNumber           int             // BatchNumber is the remittance batch of the payment.
+	BatchSequentialNumber int             // BatchSequentialNumber is the remittance sequential number of the payment in the batch.
+	Occurrences           []string        // Occurrences are up to five return codes, e.g. {"00"} for accepted or {"AG", "AN"} for rejected payments.
+	FinancingDate         time.Time       // FinancingDate is the date the payment was financed.
+	DiscountValue         decimal.Decimal // DiscountValue replaces the remittance discount value when not zero.
+	FinancingValue        decimal.Decimal // FinancingValue replaces the remittance financing value when not zero.
+	DiscountRate          decimal.Decimal // DiscountRate replaces the remittance discount rate when not zero.
+}
+
+// BillingReturnOptions holds the file identification of a return document.
+type BillingReturnOptions struct {
+	BankCode         string    // BankCode replaces the remittance bank code when informed.
+	GeneratedAt      time.Time // GeneratedAt is the file generation date and time.
+	SequentialNumber int       // SequentialNumber is the file sequential number.
+}
+
+// BillingReturnBatch is a batch of a return document.
+type BillingReturnBatch struct {
+	Header   BillingReturnBatchHeader
+	Segments []BillingReturnSegmentA
+	Trailer  BillingReturnBatchTrailer
+}
+
+// BillingReturn is a complete return document, ready to be written.
+type BillingReturn struct {
+	Header  BillingReturnFileHeader
+	Batches []BillingReturnBatch
+	Trailer BillingReturnFileTrailer
+}
+
+// NewBillingReturn builds a return document from the records of a parsed remittance and
+// the outcome of each of its payments, filling the return headers and trailers with the
+// remittance identification and totals. The remittance trailers are recomputed; any other
+// record without a return counterpart, such as a Y52 segment or a receipt, is refused
+// rather than left out of the return.
+//
+// Parameters:
+//   - remittance: The records returned by Parse for every line of the remittance file.
+//   - outcomes: The outcome of every payment (BillingSegmentA) of the remittance.
+//   - options: The identification of the return file.
+//
+// Returns:
+//   - BillingReturn: The return document.
+//   - error: An error if the remittance is malformed, holds ErrUnreturnableRecord or ErrNonNumericColumn, a payment and its outcome do not match, or an outcome is duplicated or holds an invalid occurrence code.
+//
+// Example:
+//
+//	billingReturn, err := NewBillingReturn(records, outcomes, BillingReturnOptions{GeneratedAt: time.Now(), SequentialNumber: 1})
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	lines, err := billingReturn.Lines()
+func NewBillingReturn(remittance []interface{}, outcomes []BillingOutcome, options BillingReturnOptions) (BillingReturn, error) {
+	var billingReturn BillingReturn
+	var hasHeader bool
+
+	outcomesByPayment := map[[2]int]BillingOutcome{}
+
+	for _, outcome := range outcomes {
+		key := [2]int{outcome.BatchNumber, outcome.BatchSequentialNumber}
+
+		if _, ok := outcomesByPayment[key]; ok {
+			return BillingReturn{}, wraperrors.NewErrWrap(ErrDuplicateOutcome, fmt.Errorf("at batch %d and sequential number %d", outcome.BatchNumber, outcome.BatchSequentialNumber))
+		}
+
+		outcomesByPayment[key] = outcome
+	}
+
+	for _, record := range remittance {
+		switch record := record.(type) {
+		case BillingFileHeader:
+			billingReturn.Header = newReturnFileHeader(record, options)
+			hasHeader = true
+		case BillingBatchHeader:
+			if !hasHeader {
+				return BillingReturn{}, wraperrors.NewErrWrap(ErrRemittanceWithoutHeader, fmt.Errorf("at batch %d", record.BatchNumber))
+			}
+
+			header, err := newReturnBatchHeader(record, options)
+
+			if err != nil {
+				return BillingReturn{}, err
+			}
+
+			billingReturn.Batches = append(billingReturn.Batches, BillingReturnBatch{Header: header})
+		case BillingSegmentA:
+			if len(billingReturn.Batches) == 0 {
+				return BillingReturn{}, wraperrors.NewErrWrap(ErrRemittanceWithoutHeader, fmt.Errorf("at batch %d and sequential number %d", record.BatchNumber, record.BatchSequentialNumber))
+			}
+
+			key := [2]int{record.BatchNumber, record.BatchSequentialNumber}
+			outcome, ok := outcomesByPayment[key]
+
+			if !ok {
+				return BillingReturn{}, wraperrors.NewErrWrap(ErrMissingOutcome, fmt.Errorf("at batch %d and sequential number %d", record.BatchNumber, record.BatchSequentialNumber))
+			}
+
+			delete(outcomesByPayment, key)
+
+			segment, err := newReturnSegmentA(record, outcome, options)
+
+			if err != nil {
+				return BillingReturn{}, err
+			}
+
+			batch := &billingReturn.Batches[len(billingReturn.Batches)-1]
+			batch.Segments = append(batch.Segments, segment)
+		case BillingBatchTrailer, BillingFileTrailer:
+			continue
+		default:
+			return BillingReturn{}, wraperrors.NewErrWrap(ErrUnreturnableRecord, fmt.Errorf("%T", record))
+		}
+	}
+
+	for _, outcome := range outcomes {
+		if _, ok := outcomesByPayment[[2]int{outcome.BatchNumber, outcome.BatchSequentialNumber}]; ok {
+			return BillingReturn{}, wraperrors.NewErrWrap(ErrUnknownOutcome, fmt.Errorf("at batch %d and sequential number %d", outcome.BatchNumber, outcome.BatchSequentialNumber))
+		}
+	}
+
+	billingReturn.computeTrailers()
+
+	return billingReturn, nil
+}
+
+// computeTrailers fills the batch and file trailers with the totals of the batches.
+func (b *BillingReturn) computeTrailers() {
+	fileRegistries := 2
+
+	for i := range b.Batches {
+		batch := &b.Batches[i]
+		batch.Trailer = newReturnBatchTrailer(*batch)
+		fileRegistries += batch.Trailer.QuantityRegistries
+	}
+
+	b.Trailer = BillingReturnFileTrailer{
+		BillingControl:       BillingControl{BankCode: b.Header.BankCode},
+		BatchesQuantity:      len(b.Batches),
+		FileRegistryQuantity: fileRegistries,
+	}.New()
+}
+
+// Lines writes every record of the return document, in file order.
+func (b BillingReturn) Lines() ([]string, error) {
+	var lines []string
+
+	header, err := b.Header.String()
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines = append(lines, header)
+
+	for _, batch := range b.Batches {
+		batchHeader, err := batch.Header.String()
+
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, batchHeader)
+
+		for _, segment := range batch.Segments {
+			line, err := segment.String()
+
+			if err != nil {
+				return nil, wraperrors.NewErrWrap(err, fmt.Errorf("at batch %d and sequential number %d", segment.BatchNumber, segment.BatchSequentialNumber))
+			}
+
+			lines = append(lines, line)
+		}
+
+		batchTrailer, err := batch.Trailer.String()
+
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, batchTrailer)
+	}
+
+	trailer, err := b.Trailer.String()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(lines, trailer), nil
+}
+
+// String writes the return document with lines separated by CRLF.
+func (b BillingReturn) String() (string, error) {
+	lines, err := b.Lines()
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n", nil
+}
+
+func newReturnFileHeader(header BillingFileHeader, options BillingReturnOptions) BillingReturnFileHeader {
+	return BillingReturnFileHeader{
+		BillingControl:   BillingControl{BankCode: returnBankCode(header.BankCode, options)},
+		KindBuyer:        header.KindBuyer,
+		BuyerDocument:    header.BuyerDocument,
+		ContractNumber:   header.ContractNumber,
+		BillingAccount:   header.BillingAccount,
+		BuyerName:        header.BuyerName,
+		BankName:         header.BankName,
+		FileDate:         options.GeneratedAt,
+		FileTime:         options.GeneratedAt,
+		SequentialNumber: options.SequentialNumber,
+	}.New()
+}
+
+func newReturnBatchHeader(header BillingBatchHeader, options BillingReturnOptions) (BillingReturnBatchHeader, error) {
+	var numbers columnNumbers
+
+	returnHeader := BillingReturnBatchHeader{
+		BillingControl:    BillingControl{BankCode: returnBankCode(header.BankCode, options), BatchNumber: header.BatchNumber},
+		KindBuyer:         header.KindBuyer,
+		BuyerDocument:     numbers.atoi("BuyerDocument", header.BuyerDocument),
+		ContractNumber:    header.ContractNumber,
+		BillingAccount:    header.BillingAccount,
+		BuyerName:         header.BuyerName,
+		GenericMessage:    header.GenericMessage,
+		AddressStreet:     header.AddressStreet,
+		AddressNumber:     header.AddressNumber,
+		AddressComplement: header.AddressComplement,
+		AddressCity:       header.AddressCity,
+		AddressZipCode:    header.AddressZipCode,
+		AddressState:      header.AddressState,
+	}.New()
+
+	if numbers.err != nil {
+		return BillingReturnBatchHeader{}, wraperrors.NewErrWrap(numbers.err, fmt.Errorf("at batch %d", header.BatchNumber))
+	}
+
+	return returnHeader, nil
+}
+
+func newReturnSegmentA(segment BillingSegmentA, outcome BillingOutcome, options BillingReturnOptions) (BillingReturnSegmentA, error) {
+	if len(outcome.Occurrences) > maxOccurrenceCodes {
+		return BillingReturnSegmentA{}, wraperrors.NewErrWrap(ErrTooManyOccurrenceCodes, fmt.Errorf("at batch %d and sequential number %d", segment.BatchNumber, segment.BatchSequentialNumber))
+	}
+
+	for _, code := range outcome.Occurrences {
+		if _, ok := LookupOccurrence(code); len(code) != occurrenceCodeLength || !ok {
+			return BillingReturnSegmentA{}, wraperrors.NewErrWrap(ErrInvalidOccurrenceCode, fmt.Errorf("code %q at batch %d and sequential number %d", code, segment.BatchNumber, segment.BatchSequentialNumber))
+		}
+	}
+
+	var numbers columnNumbers
+
+	returnSegment := BillingReturnSegmentA{
+		BillingControl:        BillingControl{BankCode: returnBankCode(segment.BankCode, options), BatchNumber: segment.BatchNumber},
+		BatchSequentialNumber: segment.BatchSequentialNumber,
+		VendorName:            segment.VendorName,
+		DocumentKind:          segment.DocumentKind,
+		FinancingDate:         outcome.FinancingDate,
+		Document:              segment.Document,
+		VendorBankCode:        numbers.atoi("VendorBankCode", segment.VendorBankCode),
+		VendorAgency:          numbers.atoi("VendorAgency", segment.VendorAgency),
+		VendorAgencyCd:        segment.VendorAgencyCd,
+		VendorAccount:         segment.VendorAccount,
+		VendorAccountCd:       segment.VendorAccountCd,
+		PaymentNumber:         segment.PaymentNumber,
+		IssueDate:             segment.IssueDate,
+		DueDate:               segment.DueDate,
+		PaymentValue:          segment.PaymentValue,
+		DiscountValue:         outcomeValue(outcome.DiscountValue, segment.DiscountValue),
+		FinancingValue:        outcomeValue(outcome.FinancingValue, segment.FinancingValue),
+		DiscountRate:          outcomeValue(outcome.DiscountRate, segment.DiscountRate),
+		ReferenceNumber:       referenceNumber(segment),
+		Occurrence:            strings.Join(outcome.Occurrences, ""),
+	}
+
+	if numbers.err != nil {
+		return BillingReturnSegmentA{}, wraperrors.NewErrWrap(numbers.err, fmt.Errorf("at batch %d and sequential number %d", segment.BatchNumber, segment.BatchSequentialNumber))
+	}
+
+	return returnSegment.New(), nil
+}
+
+// newReturnBatchTrailer totals a batch. Its quantity counts every segment plus the header and
+// trailer, while its value amount only sums the payments not rejected by their occurrences,
+// the payments the bank actually financed.
+func newReturnBatchTrailer(batch BillingReturnBatch) BillingReturnBatchTrailer {
+	valueAmount := decimal.Zero
+
+	for _, segment := range batch.Segments {
+		if Rejected(segment.Occurrences()) {
+			continue
+		}
+
+		valueAmount = valueAmount.Add(segment.PaymentValue)
+	}
+
+	return BillingReturnBatchTrailer{
+		BillingControl:     BillingControl{BankCode: batch.Header.BankCode, BatchNumber: batch.Header.BatchNumber},
+		QuantityRegistries: len(batch.Segments) + 2,
+		ValueAmount:        valueAmount,
+		CurrencyQuantity:   decimal.Zero,
+	}.New()
+}
+
+// referenceNumberMarkers are the markers ending the prefix of a remittance reference number,
+// as its prefixFrom and splitAfter tags declare.
+var referenceNumberMarkers = []string{"PS", "PA", "SP", "SA", "EN", "DM", "PE"}
+
+// referenceNumber joins the prefix and remainder of a remittance reference number. A column
+// without any of the markers is held whole by both fields, so it is taken once.
+func referenceNumber(segment BillingSegmentA) string {
+	for _, marker := range referenceNumberMarkers {
+		if strings.HasSuffix(segment.ReferenceNumberPrefix, marker) {
+			return segment.ReferenceNumberPrefix + segment.ReferenceNumber
+		}
+	}
+
+	return segment.ReferenceNumber
+}
+
+func returnBankCode(bankCode string, options BillingReturnOptions) string {
+	if options.BankCode != "" {
+		return options.BankCode
+	}
+
+	return bankCode
+}
+
+func outcomeValue(outcome decimal.Decimal, remittance decimal.Decimal) decimal.Decimal {
+	if outcome.IsZero() {
+		return remittance
+	}
+
+	return outcome
+}
+
+// columnNumbers converts the numeric text columns of a remittance, keeping the first error.
+type columnNumbers struct {
+	err error
+}
+
+// atoi converts a column, treating a blank column as zero.
+func (c *columnNumbers) atoi(name string, value string) int {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0
+	}
+
+	number, err := strconv.Atoi(value)
+
+	if err != nil && c.err == nil {
+		c.err = wraperrors.NewErrWrap(ErrNonNumericColumn, fmt.Errorf("field %s value %q", name, value))
+	}
+
+	return number
+}
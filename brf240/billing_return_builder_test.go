@@ -0,0 +1,218 @@
+package brf240_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/brf240"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseRemittance(t *testing.T) []interface{} {
+	lines := []string{
+		"35300000         272493216000147003320500085000000650189370000005361516 BRF S/A                       Banco Santander                         20306201921310000589206006250                                                                     ",
+		"35300011C2003060 272493216000147003320500085000000650189370000005361516 BRF S/A                       TITULO DISPONIVEL PARA NEGOCIACAO       0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000          ",
+		"3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          ",
+		"3530001300002A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014001-1-00103062019030620190000000000000000100000000000000000000000000000000000000000033PS250051005512682019002          ",
+		"35300015         000004000000000009623570000000000000000000000000                                                                                                                                                                                     ",
+		"35399999         000001000006                                                                                                                                                                                                                   ",
+	}
+
+	var records []interface{}
+
+	for _, line := range lines {
+		record, err := brf240.Parse(line)
+		assert.NoError(t, err)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNewBillingReturn(t *testing.T) {
+	generatedAt := time.Date(2024, time.September, 18, 10, 30, 15, 0, time.UTC)
+	outcomes := []brf240.BillingOutcome{
+		{
+			BatchNumber:           1,
+			BatchSequentialNumber: 1,
+			Occurrences:           []string{"00"},
+			FinancingDate:         generatedAt,
+			DiscountValue:         decimal.RequireFromString("23.57"),
+			FinancingValue:        decimal.RequireFromString("9500"),
+			DiscountRate:          decimal.RequireFromString("0.0025"),
+		},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"AG", "AN"}},
+	}
+
+	billingReturn, err := brf240.NewBillingReturn(parseRemittance(t), outcomes, brf240.BillingReturnOptions{
+		BankCode:         "BRF",
+		GeneratedAt:      generatedAt,
+		SequentialNumber: 7,
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, billingReturn.Batches, 1)
+	assert.Len(t, billingReturn.Batches[0].Segments, 2)
+	assert.Equal(t, "00", billingReturn.Batches[0].Segments[0].Occurrence)
+	assert.True(t, brf240.Rejected(billingReturn.Batches[0].Segments[1].Occurrences()))
+	assert.Equal(t, 4, billingReturn.Batches[0].Trailer.QuantityRegistries)
+	assert.Equal(t, "9523.57", billingReturn.Batches[0].Trailer.ValueAmount.StringFixed(2))
+	assert.Equal(t, 6, billingReturn.Trailer.FileRegistryQuantity)
+
+	written, err := billingReturn.String()
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(written, "\r\n"), "\r\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, "BRF00000         272493216000147003320500085000000650189370000005361516 BRF S/A                       BANCO SANTANDER                         21809202410301500000706006250                                                                     ", lines[0])
+	assert.Equal(t, "BRF0001300001A000FORNECEDOR 1                        21809202436488534000156   00033000003808      130023471000014000-1-001       03062019030620190000000000000009523570000000000235700000000950000000025000033PS25005100551268201900100        ", lines[2])
+	assert.Equal(t, "BRF0001300002A000FORNECEDOR 1                        2        36488534000156   00033000003808      130023471000014001-1-001       03062019030620190000000000000000100000000000000000000000000000000000000000033PS250051005512682019002AGAN      ", lines[3])
+	assert.Equal(t, "BRF00015         000004000000000000952357000000000000000000000000", strings.TrimRight(lines[4], " "))
+	assert.Equal(t, "BRF99999         000001000006", strings.TrimRight(lines[5], " "))
+}
+
+func TestNewBillingReturnOutcomeMismatch(t *testing.T) {
+	_, err := brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+	}, brf240.BillingReturnOptions{})
+	assert.ErrorIs(t, err, brf240.ErrMissingOutcome)
+
+	_, err = brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+		{BatchNumber: 2, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+	}, brf240.BillingReturnOptions{})
+	assert.ErrorIs(t, err, brf240.ErrUnknownOutcome)
+
+	_, err = brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"AA", "AB", "AC", "AD", "AE", "AF"}},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+	}, brf240.BillingReturnOptions{})
+	assert.ErrorIs(t, err, brf240.ErrTooManyOccurrenceCodes)
+}
+
+func TestNewBillingReturnDuplicateOutcome(t *testing.T) {
+	_, err := brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"AG"}},
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+	}, brf240.BillingReturnOptions{})
+	assert.ErrorIs(t, err, brf240.ErrDuplicateOutcome)
+}
+
+func TestNewBillingReturnInvalidOccurrenceCode(t *testing.T) {
+	for _, occurrences := range [][]string{{"0", "ABC"}, {"000"}, {"ZZ"}} {
+		_, err := brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+			{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: occurrences},
+			{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+		}, brf240.BillingReturnOptions{})
+		assert.ErrorIs(t, err, brf240.ErrInvalidOccurrenceCode, occurrences)
+	}
+}
+
+func TestNewBillingReturnReferenceNumber(t *testing.T) {
+	remittance := parseRemittance(t)
+	segment := remittance[2].(brf240.BillingSegmentA)
+	assert.Equal(t, "000033PS", segment.ReferenceNumberPrefix)
+
+	financingDate := time.Date(2024, time.September, 18, 0, 0, 0, 0, time.UTC)
+	billingReturn, err := brf240.NewBillingReturn(remittance, []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}, FinancingDate: financingDate},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}, FinancingDate: financingDate},
+	}, brf240.BillingReturnOptions{})
+	assert.NoError(t, err)
+
+	line, err := billingReturn.Batches[0].Segments[0].String()
+	assert.NoError(t, err)
+
+	definition, _ := documenttranslator.Lookup(documenttranslator.FormatBRF240Return)
+	parsed, err := definition.Parse(line)
+	assert.NoError(t, err)
+	assert.Equal(t, segment.ReferenceNumberPrefix+segment.ReferenceNumber, parsed.(brf240.BillingReturnSegmentA).ReferenceNumber)
+}
+
+func TestNewBillingReturnReferenceNumberWithoutMarker(t *testing.T) {
+	remittance := parseRemittance(t)
+	line, err := remittance[3].(brf240.BillingSegmentA).String()
+	assert.NoError(t, err)
+
+	record, err := brf240.Parse(line[:201] + "XYZ12345" + strings.Repeat(" ", 21) + line[230:])
+	assert.NoError(t, err)
+	remittance[3] = record
+
+	billingReturn, err := brf240.NewBillingReturn(remittance, []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+	}, brf240.BillingReturnOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "XYZ12345", billingReturn.Batches[0].Segments[1].ReferenceNumber)
+}
+
+func TestNewBillingReturnRemittanceErrors(t *testing.T) {
+	outcomes := []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(remittance []interface{}) []interface{}
+		err    error
+	}{
+		{
+			name: "alphanumeric buyer document",
+			modify: func(remittance []interface{}) []interface{} {
+				header := remittance[1].(brf240.BillingBatchHeader)
+				header.BuyerDocument = "72493216A00147"
+				remittance[1] = header
+
+				return remittance
+			},
+			err: brf240.ErrNonNumericColumn,
+		},
+		{
+			name: "alphanumeric vendor bank code",
+			modify: func(remittance []interface{}) []interface{} {
+				segment := remittance[2].(brf240.BillingSegmentA)
+				segment.VendorBankCode = "BRF"
+				remittance[2] = segment
+
+				return remittance
+			},
+			err: brf240.ErrNonNumericColumn,
+		},
+		{
+			name: "blank agency",
+			modify: func(remittance []interface{}) []interface{} {
+				header := remittance[0].(brf240.BillingFileHeader)
+				header.Agency = "  "
+				remittance[0] = header
+
+				return remittance
+			},
+		},
+		{
+			name: "Y52 segment",
+			modify: func(remittance []interface{}) []interface{} {
+				return append(remittance[:3:3], append([]interface{}{brf240.BillingSegmentY52{BillingControl: brf240.BillingControl{BatchNumber: 1}}}, remittance[3:]...)...)
+			},
+			err: brf240.ErrUnreturnableRecord,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := brf240.NewBillingReturn(test.modify(parseRemittance(t)), outcomes, brf240.BillingReturnOptions{})
+
+			if test.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
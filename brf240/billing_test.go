@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	documenttranslator "github.com/libercapital/document-translator-go"
 	"github.com/libercapital/document-translator-go/brf240"
 
 	"github.com/shopspring/decimal"
@@ -14,17 +15,12 @@ func TestBillingFileHeaderParse(t *testing.T) {
 	file_header := "35300000         272493216000147003320500085000000650189370000005361516 BRF S/A                       Banco Santander                         20306201921310000589206006250                                                                     "
 
 	expected_header := brf240.BillingFileHeader{
-		BankCode:         "353",
-		BatchNumber:      0,
+		BillingControl:   brf240.BillingControl{BankCode: "353", BatchNumber: 0},
 		RegistryKind:     0,
 		KindBuyer:        2,
 		BuyerDocument:    "72493216000147",
 		ContractNumber:   "00332050008500000065",
-		Agency:           "1893",
-		AgencyCd:         "7",
-		Account:          "536151",
-		AccountCd:        "6",
-		CheckDigit:       "",
+		BillingAccount:   brf240.BillingAccount{Agency: "1893", AgencyCd: "7", Account: "536151", AccountCd: "6", CheckDigit: ""},
 		BuyerName:        "BRF S/A",
 		BankName:         "Banco Santander",
 		FileKind:         2,
@@ -47,8 +43,7 @@ func TestBillingBatchHeaderParse(t *testing.T) {
 	file_batch_header := "35300011C2003060 272493216000147003320500085000000650189370000005361516 BRF S/A                       TITULO DISPONIVEL PARA NEGOCIACAO       0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000          "
 
 	expected_batch_header := brf240.BillingBatchHeader{
-		BankCode:           "353",
-		BatchNumber:        1,
+		BillingControl:     brf240.BillingControl{BankCode: "353", BatchNumber: 1},
 		RegistryKind:       1,
 		OperationKind:      "C",
 		ServiceKind:        20,
@@ -57,11 +52,7 @@ func TestBillingBatchHeaderParse(t *testing.T) {
 		KindBuyer:          2,
 		BuyerDocument:      "72493216000147",
 		ContractNumber:     "00332050008500000065",
-		Agency:             "1893",
-		AgencyCd:           "7",
-		Account:            "536151",
-		AccountCd:          "6",
-		CheckDigit:         "",
+		BillingAccount:     brf240.BillingAccount{Agency: "1893", AgencyCd: "7", Account: "536151", AccountCd: "6", CheckDigit: ""},
 		BuyerName:          "BRF S/A",
 		GenericMessage:     "TITULO DISPONIVEL PARA NEGOCIACAO",
 		AddressStreet:      "000000000000000000000000000000",
@@ -71,6 +62,7 @@ func TestBillingBatchHeaderParse(t *testing.T) {
 		AddressZipCode:     0,
 		AddressState:       "00",
 		PaymentMethod:      "00",
+		Filler:             "000000",
 		Occurrence:         "",
 	}
 	parsed, err := brf240.Parse(file_batch_header)
@@ -87,8 +79,7 @@ func TestBillingBillingSegmentAParse(t *testing.T) {
 	expected_discount_rate, _ := decimal.NewFromString("0.0000")
 
 	expected_segment_a := brf240.BillingSegmentA{
-		BankCode:              "353",
-		BatchNumber:           1,
+		BillingControl:        brf240.BillingControl{BankCode: "353", BatchNumber: 1},
 		RegistryKind:          3,
 		BatchSequentialNumber: 1,
 		SegmentKind:           "A",
@@ -120,12 +111,45 @@ func TestBillingBillingSegmentAParse(t *testing.T) {
 	assert.Equal(t, expected_segment_a, parsed)
 }
 
+func TestBillingSegmentAParseInvalidCheckDigits(t *testing.T) {
+	file_segment_a := "3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          "
+
+	_, err := brf240.Parse(file_segment_a[:107] + "2" + file_segment_a[108:])
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.ErrorContains(t, err, "VendorAccountCd")
+
+	_, err = brf240.Parse(file_segment_a[:62] + "00036488534000157" + file_segment_a[79:])
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.ErrorContains(t, err, "Document")
+}
+
+func TestBillingSegmentAParseInvalidDocumentKind(t *testing.T) {
+	file_segment_a := "3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          "
+
+	_, err := brf240.Parse(file_segment_a[:53] + "0" + file_segment_a[54:])
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.ErrorContains(t, err, "DocumentKind")
+}
+
+func TestBillingSegmentAParseUncheckableAccount(t *testing.T) {
+	file_segment_a := "3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          "
+
+	// Bradesco numbers accounts with 7 digits, so the digit of a wider account can't be checked.
+	parsed, err := brf240.Parse(file_segment_a[:79] + "002370000014257" + "00001234567891" + file_segment_a[108:])
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789", parsed.(brf240.BillingSegmentA).VendorAccount)
+
+	_, err = brf240.Parse(file_segment_a[:79] + "002370000014258" + "00001234567891" + file_segment_a[108:])
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.ErrorContains(t, err, "VendorAgencyCd")
+}
+
 func TestBillingSegmentAReceiptParse(t *testing.T) {
 	file_segment_a := "BRF0001300001A112G10 TRANSPORTES LTDA                2           0756916100049200000000000000         732807     000085997-001-001180920200000000000000000000000000000000000000000000000000059454850255425BDBRFSA25005102375484201900112          "
 
 	expected_segment_a := brf240.BillingSegmentAReceipt{
-		BankCode:              "BRF",
-		BatchNumber:           1,
+		BillingControl:        brf240.BillingControl{BankCode: "BRF", BatchNumber: 1},
 		RegistryKind:          3,
 		BatchSequentialNumber: 1,
 		SegmentKind:           "A",
@@ -159,21 +183,16 @@ func TestBillingBillingSegmentY52Parse(t *testing.T) {
 	file_segment_y52 := "2370001300001Y 00520000000000120510000000004431823009202341230900766315001035570200000120511001205197                                                                                                                                          "
 
 	expected_segment_y52 := brf240.BillingSegmentY52{
-		BankCode:              "237",
-		BatchNumber:           1,
+		BillingControl:        brf240.BillingControl{BankCode: "237", BatchNumber: 1},
 		RegistryKind:          3,
 		BatchSequentialNumber: 1,
 		SegmentKind:           "Y",
 		ActionInstructionKind: 0,
 		OptionalRegistryId:    "52",
-		FiscalDocumentNumber1: "12051",
-		FiscalDocumentValue1:  "443182",
-		FiscalDocumentDate1:   "30092023",
-		FiscalDocumentKey1:    "41230900766315001035570200000120511001205197",
-		FiscalDocumentNumber2: "",
-		FiscalDocumentValue2:  "",
-		FiscalDocumentDate2:   "",
-		FiscalDocumentKey2:    "",
+		FiscalDocuments: [2]brf240.BillingFiscalDocument{
+			{Number: "12051", Value: "443182", Date: "30092023", Key: "41230900766315001035570200000120511001205197"},
+			{},
+		},
 	}
 	parsed, err := brf240.Parse(file_segment_y52)
 
@@ -186,8 +205,7 @@ func TestBillingBatchTrailerParse(t *testing.T) {
 	expected_value_amount, _ := decimal.NewFromString("404201707.31")
 
 	expected_file_trailer := brf240.BillingBatchTrailer{
-		BankCode:                "353",
-		BatchNumber:             1,
+		BillingControl:          brf240.BillingControl{BankCode: "353", BatchNumber: 1},
 		RegistryKind:            5,
 		QuantityRegistries:      35193,
 		ValueAmount:             expected_value_amount,
@@ -204,8 +222,7 @@ func TestBillingFileTrailerParse(t *testing.T) {
 	file_trailer := "35399999         000001035195                                                                                                                                                                                                                   "
 
 	expected_file_trailer := brf240.BillingFileTrailer{
-		BankCode:             "353",
-		BatchNumber:          9999,
+		BillingControl:       brf240.BillingControl{BankCode: "353", BatchNumber: 9999},
 		RegistryKind:         9,
 		BatchesQuantity:      1,
 		FileRegistryQuantity: 35195,
@@ -224,8 +241,7 @@ func TestBillingReturnSegmentAWrite(t *testing.T) {
 	expected_segment_a := "BRF0001300001A000G10 TRANSPORTES LTDA                21809202407569161000492   003410000032883        732807000085997-001-001     18092024180920240000000000000000022500000000000025000000000002000000064250051023754842019001        F5        "
 
 	segment_a := brf240.BillingReturnSegmentA{
-		BankCode:              "BRF",
-		BatchNumber:           1,
+		BillingControl:        brf240.BillingControl{BankCode: "BRF", BatchNumber: 1},
 		RegistryKind:          3,
 		BatchSequentialNumber: 1,
 		SegmentKind:           "A",
@@ -255,3 +271,42 @@ func TestBillingReturnSegmentAWrite(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected_segment_a, segment_a_written)
 }
+
+func TestBillingReturnBatchHeaderNew(t *testing.T) {
+	header := brf240.BillingReturnBatchHeader{OperationKind: "D"}.New()
+
+	assert.Equal(t, 1, header.RegistryKind)
+	assert.Equal(t, "D", header.OperationKind)
+	assert.Equal(t, 20, header.ServiceKind)
+	assert.Equal(t, 3, header.ReleaseKind)
+	assert.Equal(t, 60, header.BatchLayoutVersion)
+}
+
+func TestBillingReturnNewDefaults(t *testing.T) {
+	records := []interface{}{
+		&brf240.BillingReturnFileHeader{},
+		&brf240.BillingReturnBatchHeader{},
+		&brf240.BillingReturnSegmentA{},
+		&brf240.BillingReturnBatchTrailer{},
+		&brf240.BillingReturnFileTrailer{},
+	}
+
+	for _, record := range records {
+		assert.NoError(t, documenttranslator.FillDefaults(record), "%T", record)
+	}
+
+	fileHeader := brf240.BillingReturnFileHeader{}.New()
+	assert.Equal(t, 2, fileHeader.FileKind)
+	assert.Equal(t, "060", fileHeader.LayoutVersion)
+	assert.Equal(t, 6250, fileHeader.RecordDensity)
+
+	segment := brf240.BillingReturnSegmentA{}.New()
+	assert.Equal(t, 3, segment.RegistryKind)
+	assert.Equal(t, "A", segment.SegmentKind)
+
+	assert.Equal(t, 5, brf240.BillingReturnBatchTrailer{}.New().RegistryKind)
+
+	fileTrailer := brf240.BillingReturnFileTrailer{}.New()
+	assert.Equal(t, 9, fileTrailer.RegistryKind)
+	assert.Equal(t, 9999, fileTrailer.BatchNumber)
+}
@@ -0,0 +1,143 @@
+package brf240
+
+import (
+	"errors"
+	"fmt"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+var ErrMisplacedRecord = errors.New("record out of the file header, batches and file trailer order")
+
+// finalizeRemittance completes an imported remittance with the totals of its batch and file
+// trailers, replacing any trailer of the records.
+func finalizeRemittance(records []documenttranslator.Record) ([]documenttranslator.Record, error) {
+	var finalized []documenttranslator.Record
+	var header BillingFileHeader
+	var batchHeader *BillingBatchHeader
+
+	batches, fileRegistries, batchRegistries := 0, 2, 0
+	valueAmount := decimal.Zero
+
+	closeBatch := func() {
+		if batchHeader == nil {
+			return
+		}
+
+		finalized = append(finalized, BillingBatchTrailer{
+			BillingControl:     BillingControl{BankCode: batchHeader.BankCode, BatchNumber: batchHeader.BatchNumber},
+			RegistryKind:       5,
+			QuantityRegistries: batchRegistries + 2,
+			ValueAmount:        valueAmount,
+		})
+		batches++
+		fileRegistries += batchRegistries + 2
+		batchHeader = nil
+	}
+
+	for i, record := range records {
+		switch record := record.(type) {
+		case BillingFileHeader:
+			if i != 0 {
+				return nil, misplacedRecord(i)
+			}
+
+			header = record
+			finalized = append(finalized, record)
+		case BillingBatchHeader:
+			if len(finalized) == 0 {
+				return nil, misplacedRecord(i)
+			}
+
+			closeBatch()
+			batchHeader, batchRegistries, valueAmount = &record, 0, decimal.Zero
+			finalized = append(finalized, record)
+		case BillingBatchTrailer, BillingFileTrailer:
+			continue
+		default:
+			if batchHeader == nil {
+				return nil, misplacedRecord(i)
+			}
+
+			if segment, ok := record.(BillingSegmentA); ok {
+				valueAmount = valueAmount.Add(segment.PaymentValue)
+			}
+
+			batchRegistries++
+			finalized = append(finalized, record)
+		}
+	}
+
+	if len(finalized) == 0 {
+		return nil, misplacedRecord(0)
+	}
+
+	closeBatch()
+
+	return append(finalized, BillingFileTrailer{
+		BillingControl:       BillingControl{BankCode: header.BankCode, BatchNumber: 9999},
+		RegistryKind:         9,
+		BatchesQuantity:      batches,
+		FileRegistryQuantity: fileRegistries,
+	}), nil
+}
+
+// finalizeReturn completes an imported return document with the totals of its batch and file
+// trailers, as NewBillingReturn does, replacing any trailer of the records.
+func finalizeReturn(records []documenttranslator.Record) ([]documenttranslator.Record, error) {
+	var billingReturn BillingReturn
+
+	if len(records) == 0 {
+		return nil, misplacedRecord(0)
+	}
+
+	if _, ok := records[0].(BillingReturnFileHeader); !ok {
+		return nil, misplacedRecord(0)
+	}
+
+	for i, record := range records {
+		switch record := record.(type) {
+		case BillingReturnFileHeader:
+			if i != 0 {
+				return nil, misplacedRecord(i)
+			}
+
+			billingReturn.Header = record
+		case BillingReturnBatchHeader:
+			billingReturn.Batches = append(billingReturn.Batches, BillingReturnBatch{Header: record})
+		case BillingReturnSegmentA:
+			if len(billingReturn.Batches) == 0 {
+				return nil, misplacedRecord(i)
+			}
+
+			batch := &billingReturn.Batches[len(billingReturn.Batches)-1]
+			batch.Segments = append(batch.Segments, record)
+		case BillingReturnBatchTrailer, BillingReturnFileTrailer:
+			continue
+		default:
+			return nil, misplacedRecord(i)
+		}
+	}
+
+	billingReturn.computeTrailers()
+
+	finalized := []documenttranslator.Record{billingReturn.Header}
+
+	for _, batch := range billingReturn.Batches {
+		finalized = append(finalized, batch.Header)
+
+		for _, segment := range batch.Segments {
+			finalized = append(finalized, segment)
+		}
+
+		finalized = append(finalized, batch.Trailer)
+	}
+
+	return append(finalized, billingReturn.Trailer), nil
+}
+
+func misplacedRecord(index int) error {
+	return wraperrors.NewErrWrap(ErrMisplacedRecord, fmt.Errorf("at record %d", index+1))
+}
@@ -0,0 +1,90 @@
+package brf240_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/brf240"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBillingReturn(t *testing.T) {
+	generatedAt := time.Date(2024, time.September, 18, 10, 30, 15, 0, time.UTC)
+	billingReturn, err := brf240.NewBillingReturn(parseRemittance(t), []brf240.BillingOutcome{
+		{BatchNumber: 1, BatchSequentialNumber: 1, Occurrences: []string{"00"}, FinancingDate: generatedAt},
+		{BatchNumber: 1, BatchSequentialNumber: 2, Occurrences: []string{"00"}, FinancingDate: generatedAt},
+	}, brf240.BillingReturnOptions{BankCode: "BRF", GeneratedAt: generatedAt, SequentialNumber: 7})
+	assert.NoError(t, err)
+
+	file, err := billingReturn.String()
+	assert.NoError(t, err)
+
+	var exported bytes.Buffer
+
+	err = documenttranslator.ExportJSONLines(documenttranslator.FormatBRF240Return, strings.NewReader(file), &exported, documenttranslator.JSONLinesOptions{})
+	assert.NoError(t, err)
+
+	// The services importing a return describe its headers and segments, without trailers
+	// nor the constant columns of the layout.
+	var input, trailers []string
+
+	for _, line := range strings.Split(strings.TrimSpace(exported.String()), "\n") {
+		if strings.Contains(line, `"record_type":"5"`) || strings.Contains(line, `"record_type":"9"`) {
+			trailers = append(trailers, line)
+			continue
+		}
+
+		input = append(input, strings.Replace(line, `"RegistryKind":3,`, "", 1))
+	}
+
+	assert.Len(t, input, 4)
+
+	document, err := documenttranslator.ImportJSONLines(documenttranslator.FormatBRF240Return, strings.NewReader(strings.Join(input, "\n")), documenttranslator.JSONLinesOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, document.Records, 6)
+
+	imported, err := document.String()
+	assert.NoError(t, err)
+	assert.Equal(t, file, imported)
+
+	withoutHeader := append([]string{trailers[len(trailers)-1]}, input[1:]...)
+	_, err = documenttranslator.ImportJSONLines(documenttranslator.FormatBRF240Return, strings.NewReader(strings.Join(withoutHeader, "\n")), documenttranslator.JSONLinesOptions{})
+	assert.ErrorIs(t, err, brf240.ErrMisplacedRecord)
+}
+
+func TestImportRemittance(t *testing.T) {
+	var lines []string
+
+	for _, record := range parseRemittance(t)[:4] {
+		line, err := record.(documenttranslator.Record).String()
+		assert.NoError(t, err)
+		lines = append(lines, line)
+	}
+
+	var exported bytes.Buffer
+
+	err := documenttranslator.ExportJSONLines(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(lines, "\n")), &exported, documenttranslator.JSONLinesOptions{Naming: documenttranslator.FieldNamingSnakeCase})
+	assert.NoError(t, err)
+
+	input := strings.Split(strings.TrimSpace(exported.String()), "\n")
+	options := documenttranslator.JSONLinesOptions{Naming: documenttranslator.FieldNamingSnakeCase}
+
+	document, err := documenttranslator.ImportJSONLines(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(input, "\n")), options)
+	assert.NoError(t, err)
+	assert.Len(t, document.Records, 6)
+
+	batchTrailer := document.Records[4].(brf240.BillingBatchTrailer)
+	assert.Equal(t, 4, batchTrailer.QuantityRegistries)
+	assert.Equal(t, "9623.57", batchTrailer.ValueAmount.StringFixed(2))
+
+	fileTrailer := document.Records[5].(brf240.BillingFileTrailer)
+	assert.Equal(t, 1, fileTrailer.BatchesQuantity)
+	assert.Equal(t, 6, fileTrailer.FileRegistryQuantity)
+	assert.Equal(t, 9999, fileTrailer.BatchNumber)
+
+	_, err = documenttranslator.ImportJSONLines(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(input[1:], "\n")), options)
+	assert.ErrorIs(t, err, brf240.ErrMisplacedRecord)
+}
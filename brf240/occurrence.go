@@ -0,0 +1,218 @@
+package brf240
+
+import (
+	"strings"
+)
+
+// OccurrenceClass classifies how an occurrence code affects the record it is returned for.
+type OccurrenceClass int
+
+const (
+	OccurrenceUnknown       OccurrenceClass = iota // OccurrenceUnknown is a code missing from the catalog.
+	OccurrenceAccepted                             // OccurrenceAccepted confirms the record was processed.
+	OccurrenceRejected                             // OccurrenceRejected explains why the record was refused.
+	OccurrenceInformational                        // OccurrenceInformational reports a fact that does not refuse the record.
+)
+
+func (c OccurrenceClass) String() string {
+	switch c {
+	case OccurrenceAccepted:
+		return "accepted"
+	case OccurrenceRejected:
+		return "rejected"
+	case OccurrenceInformational:
+		return "informational"
+	}
+
+	return "unknown"
+}
+
+// Occurrence is a FEBRABAN return code with its descriptions.
+type Occurrence struct {
+	Code          string          // Code is the two characters return code, e.g. "AG".
+	DescriptionPT string          // DescriptionPT is the description in Portuguese, as in the FEBRABAN manual.
+	DescriptionEN string          // DescriptionEN is the description in English.
+	Class         OccurrenceClass // Class tells whether the code accepts, rejects or just informs.
+}
+
+const occurrenceCodeLength = 2
+
+// occurrenceCatalog holds the payment return codes of the FEBRABAN CNAB 240 manual (note G059).
+var occurrenceCatalog = map[string]Occurrence{
+	"00": {DescriptionPT: "Crédito ou Débito Efetivado", DescriptionEN: "Credit or debit carried out", Class: OccurrenceAccepted},
+	"01": {DescriptionPT: "Insuficiência de Fundos - Débito Não Efetuado", DescriptionEN: "Insufficient funds - debit not carried out", Class: OccurrenceRejected},
+	"02": {DescriptionPT: "Crédito ou Débito Cancelado pelo Pagador/Credor", DescriptionEN: "Credit or debit cancelled by the payer/creditor", Class: OccurrenceRejected},
+	"03": {DescriptionPT: "Débito Autorizado pela Agência - Efetuado", DescriptionEN: "Debit authorized by the branch - carried out", Class: OccurrenceAccepted},
+	"AA": {DescriptionPT: "Controle Inválido", DescriptionEN: "Invalid control", Class: OccurrenceRejected},
+	"AB": {DescriptionPT: "Tipo de Operação Inválido", DescriptionEN: "Invalid operation type", Class: OccurrenceRejected},
+	"AC": {DescriptionPT: "Tipo de Serviço Inválido", DescriptionEN: "Invalid service type", Class: OccurrenceRejected},
+	"AD": {DescriptionPT: "Forma de Lançamento Inválida", DescriptionEN: "Invalid entry method", Class: OccurrenceRejected},
+	"AE": {DescriptionPT: "Tipo/Número de Inscrição Inválido", DescriptionEN: "Invalid registration type/number", Class: OccurrenceRejected},
+	"AF": {DescriptionPT: "Código de Convênio Inválido", DescriptionEN: "Invalid agreement code", Class: OccurrenceRejected},
+	"AG": {DescriptionPT: "Agência/Conta Corrente/DV Inválido", DescriptionEN: "Invalid branch/checking account/check digit", Class: OccurrenceRejected},
+	"AH": {DescriptionPT: "Nº Seqüencial do Registro no Lote Inválido", DescriptionEN: "Invalid record sequential number in the batch", Class: OccurrenceRejected},
+	"AI": {DescriptionPT: "Código de Segmento de Detalhe Inválido", DescriptionEN: "Invalid detail segment code", Class: OccurrenceRejected},
+	"AJ": {DescriptionPT: "Tipo de Movimento Inválido", DescriptionEN: "Invalid movement type", Class: OccurrenceRejected},
+	"AK": {DescriptionPT: "Código da Câmara de Compensação do Banco Favorecido/Depositário Inválido", DescriptionEN: "Invalid clearing house code of the payee/depositary bank", Class: OccurrenceRejected},
+	"AL": {DescriptionPT: "Código do Banco Favorecido, Instituição de Pagamento ou Depositário Inválido", DescriptionEN: "Invalid payee bank, payment institution or depositary code", Class: OccurrenceRejected},
+	"AM": {DescriptionPT: "Agência Mantenedora da Conta Corrente do Favorecido Inválida", DescriptionEN: "Invalid branch of the payee checking account", Class: OccurrenceRejected},
+	"AN": {DescriptionPT: "Conta Corrente/DV/Conta de Pagamento do Favorecido Inválido", DescriptionEN: "Invalid payee checking account/check digit/payment account", Class: OccurrenceRejected},
+	"AO": {DescriptionPT: "Nome do Favorecido Não Informado", DescriptionEN: "Payee name not informed", Class: OccurrenceRejected},
+	"AP": {DescriptionPT: "Data Lançamento Inválido", DescriptionEN: "Invalid entry date", Class: OccurrenceRejected},
+	"AQ": {DescriptionPT: "Tipo/Quantidade da Moeda Inválido", DescriptionEN: "Invalid currency type/amount", Class: OccurrenceRejected},
+	"AR": {DescriptionPT: "Valor do Lançamento Inválido", DescriptionEN: "Invalid entry value", Class: OccurrenceRejected},
+	"AS": {DescriptionPT: "Aviso ao Favorecido - Identificação Inválida", DescriptionEN: "Payee notice - invalid identification", Class: OccurrenceRejected},
+	"AT": {DescriptionPT: "Tipo/Número de Inscrição do Favorecido Inválido", DescriptionEN: "Invalid payee registration type/number", Class: OccurrenceRejected},
+	"AU": {DescriptionPT: "Logradouro do Favorecido Não Informado", DescriptionEN: "Payee street not informed", Class: OccurrenceRejected},
+	"AV": {DescriptionPT: "Nº do Local do Favorecido Não Informado", DescriptionEN: "Payee address number not informed", Class: OccurrenceRejected},
+	"AW": {DescriptionPT: "Cidade do Favorecido Não Informada", DescriptionEN: "Payee city not informed", Class: OccurrenceRejected},
+	"AX": {DescriptionPT: "CEP/Complemento do Favorecido Inválido", DescriptionEN: "Invalid payee zip code/complement", Class: OccurrenceRejected},
+	"AY": {DescriptionPT: "Sigla do Estado do Favorecido Inválida", DescriptionEN: "Invalid payee state abbreviation", Class: OccurrenceRejected},
+	"AZ": {DescriptionPT: "Código/Nome do Banco Depositário Inválido", DescriptionEN: "Invalid depositary bank code/name", Class: OccurrenceRejected},
+	"BA": {DescriptionPT: "Código/Nome da Agência Depositária Não Informado", DescriptionEN: "Depositary branch code/name not informed", Class: OccurrenceRejected},
+	"BB": {DescriptionPT: "Seu Número Inválido", DescriptionEN: "Invalid company reference number", Class: OccurrenceRejected},
+	"BC": {DescriptionPT: "Nosso Número Inválido", DescriptionEN: "Invalid bank reference number", Class: OccurrenceRejected},
+	"BD": {DescriptionPT: "Inclusão Efetuada com Sucesso", DescriptionEN: "Successfully included", Class: OccurrenceAccepted},
+	"BE": {DescriptionPT: "Alteração Efetuada com Sucesso", DescriptionEN: "Successfully changed", Class: OccurrenceAccepted},
+	"BF": {DescriptionPT: "Exclusão Efetuada com Sucesso", DescriptionEN: "Successfully excluded", Class: OccurrenceAccepted},
+	"BG": {DescriptionPT: "Agência/Conta Impedida Legalmente", DescriptionEN: "Branch/account legally blocked", Class: OccurrenceRejected},
+	"CA": {DescriptionPT: "Código de Barras - Código do Banco Inválido", DescriptionEN: "Barcode - invalid bank code", Class: OccurrenceRejected},
+	"CB": {DescriptionPT: "Código de Barras - Código da Moeda Inválido", DescriptionEN: "Barcode - invalid currency code", Class: OccurrenceRejected},
+	"CC": {DescriptionPT: "Código de Barras - Dígito Verificador Geral Inválido", DescriptionEN: "Barcode - invalid general check digit", Class: OccurrenceRejected},
+	"CD": {DescriptionPT: "Código de Barras - Valor do Título Inválido", DescriptionEN: "Barcode - invalid bill value", Class: OccurrenceRejected},
+	"CE": {DescriptionPT: "Código de Barras - Campo Livre Inválido", DescriptionEN: "Barcode - invalid free field", Class: OccurrenceRejected},
+	"CF": {DescriptionPT: "Valor do Documento Inválido", DescriptionEN: "Invalid document value", Class: OccurrenceRejected},
+	"CG": {DescriptionPT: "Valor do Abatimento Inválido", DescriptionEN: "Invalid rebate value", Class: OccurrenceRejected},
+	"CH": {DescriptionPT: "Valor do Desconto Inválido", DescriptionEN: "Invalid discount value", Class: OccurrenceRejected},
+	"CI": {DescriptionPT: "Valor de Mora Inválido", DescriptionEN: "Invalid late interest value", Class: OccurrenceRejected},
+	"CJ": {DescriptionPT: "Valor da Multa Inválido", DescriptionEN: "Invalid fine value", Class: OccurrenceRejected},
+	"CK": {DescriptionPT: "Valor do IR Inválido", DescriptionEN: "Invalid income tax value", Class: OccurrenceRejected},
+	"CL": {DescriptionPT: "Valor do ISS Inválido", DescriptionEN: "Invalid service tax value", Class: OccurrenceRejected},
+	"CM": {DescriptionPT: "Valor do IOF Inválido", DescriptionEN: "Invalid IOF tax value", Class: OccurrenceRejected},
+	"CN": {DescriptionPT: "Valor de Outras Deduções Inválido", DescriptionEN: "Invalid other deductions value", Class: OccurrenceRejected},
+	"CO": {DescriptionPT: "Valor de Outros Acréscimos Inválido", DescriptionEN: "Invalid other additions value", Class: OccurrenceRejected},
+	"CP": {DescriptionPT: "Valor do INSS Inválido", DescriptionEN: "Invalid social security value", Class: OccurrenceRejected},
+	"HA": {DescriptionPT: "Lote Não Aceito", DescriptionEN: "Batch not accepted", Class: OccurrenceRejected},
+	"HB": {DescriptionPT: "Inscrição da Empresa Inválida para o Contrato", DescriptionEN: "Company registration invalid for the contract", Class: OccurrenceRejected},
+	"HC": {DescriptionPT: "Convênio com a Empresa Inexistente/Inválido para o Contrato", DescriptionEN: "Company agreement missing/invalid for the contract", Class: OccurrenceRejected},
+	"HD": {DescriptionPT: "Agência/Conta Corrente da Empresa Inexistente/Inválido para o Contrato", DescriptionEN: "Company branch/checking account missing/invalid for the contract", Class: OccurrenceRejected},
+	"HE": {DescriptionPT: "Tipo de Serviço Inválido para o Contrato", DescriptionEN: "Service type invalid for the contract", Class: OccurrenceRejected},
+	"HF": {DescriptionPT: "Conta Corrente da Empresa com Saldo Insuficiente", DescriptionEN: "Company checking account with insufficient balance", Class: OccurrenceRejected},
+	"HG": {DescriptionPT: "Lote de Serviço Fora de Seqüência", DescriptionEN: "Service batch out of sequence", Class: OccurrenceRejected},
+	"HH": {DescriptionPT: "Lote de Serviço Inválido", DescriptionEN: "Invalid service batch", Class: OccurrenceRejected},
+	"HI": {DescriptionPT: "Arquivo não aceito", DescriptionEN: "File not accepted", Class: OccurrenceRejected},
+	"HJ": {DescriptionPT: "Tipo de Registro Inválido", DescriptionEN: "Invalid record type", Class: OccurrenceRejected},
+	"HK": {DescriptionPT: "Código Remessa / Retorno Inválido", DescriptionEN: "Invalid remittance/return code", Class: OccurrenceRejected},
+	"HL": {DescriptionPT: "Versão de layout inválida", DescriptionEN: "Invalid layout version", Class: OccurrenceRejected},
+	"TA": {DescriptionPT: "Lote Não Aceito - Totais do Lote com Diferença", DescriptionEN: "Batch not accepted - batch totals do not match", Class: OccurrenceRejected},
+	"YA": {DescriptionPT: "Título Não Encontrado", DescriptionEN: "Bill not found", Class: OccurrenceRejected},
+	"YB": {DescriptionPT: "Identificador Registro Opcional Inválido", DescriptionEN: "Invalid optional record identifier", Class: OccurrenceRejected},
+	"YC": {DescriptionPT: "Código Padrão Inválido", DescriptionEN: "Invalid standard code", Class: OccurrenceRejected},
+	"YD": {DescriptionPT: "Código de Ocorrência Inválido", DescriptionEN: "Invalid occurrence code", Class: OccurrenceRejected},
+	"YE": {DescriptionPT: "Complemento de Ocorrência Inválido", DescriptionEN: "Invalid occurrence complement", Class: OccurrenceRejected},
+	"YF": {DescriptionPT: "Alegação já Informada", DescriptionEN: "Allegation already informed", Class: OccurrenceRejected},
+	"ZA": {DescriptionPT: "Agência/Conta do Favorecido Substituída", DescriptionEN: "Payee branch/account replaced", Class: OccurrenceInformational},
+	"ZB": {DescriptionPT: "Divergência entre o primeiro e último nome do beneficiário versus primeiro e último nome na Receita Federal", DescriptionEN: "Beneficiary first and last name differ from the Federal Revenue records", Class: OccurrenceInformational},
+	"ZC": {DescriptionPT: "Confirmação de Antecipação de Valor", DescriptionEN: "Value anticipation confirmed", Class: OccurrenceAccepted},
+	"ZD": {DescriptionPT: "Antecipação Parcial de Valor", DescriptionEN: "Partial value anticipation", Class: OccurrenceInformational},
+	"ZE": {DescriptionPT: "Título bloqueado na base", DescriptionEN: "Bill blocked in the database", Class: OccurrenceRejected},
+	"ZF": {DescriptionPT: "Sistema em contingência – título valor maior que referência", DescriptionEN: "System in contingency - bill value above the reference", Class: OccurrenceRejected},
+	"ZG": {DescriptionPT: "Sistema em contingência – título vencido", DescriptionEN: "System in contingency - overdue bill", Class: OccurrenceRejected},
+	"ZH": {DescriptionPT: "Sistema em contingência – título indexado", DescriptionEN: "System in contingency - indexed bill", Class: OccurrenceRejected},
+	"ZI": {DescriptionPT: "Beneficiário divergente", DescriptionEN: "Divergent beneficiary", Class: OccurrenceRejected},
+	"ZJ": {DescriptionPT: "Limite de pagamentos parciais excedido", DescriptionEN: "Partial payments limit exceeded", Class: OccurrenceRejected},
+	"ZK": {DescriptionPT: "Boleto já liquidado", DescriptionEN: "Bill already settled", Class: OccurrenceRejected},
+}
+
+// LookupOccurrence returns the catalog entry of a return code. Codes missing from the catalog,
+// such as bank specific ones, are returned with OccurrenceUnknown and false.
+func LookupOccurrence(code string) (Occurrence, bool) {
+	code = strings.ToUpper(code)
+	occurrence, ok := occurrenceCatalog[code]
+
+	if !ok {
+		return Occurrence{
+			Code:          code,
+			DescriptionPT: "Código de ocorrência não catalogado",
+			DescriptionEN: "Occurrence code not in the catalog",
+			Class:         OccurrenceUnknown,
+		}, false
+	}
+
+	occurrence.Code = code
+
+	return occurrence, true
+}
+
+// ParseOccurrences splits an occurrence column, holding up to five two characters codes,
+// into its catalog entries. Blank codes are skipped.
+//
+// Example:
+//
+//	occurrences := ParseOccurrences("AGAN      ") // AG and AN, both rejected
+func ParseOccurrences(column string) (occurrences []Occurrence) {
+	for start := 0; start < len(column); start += occurrenceCodeLength {
+		end := start + occurrenceCodeLength
+
+		if end > len(column) {
+			end = len(column)
+		}
+
+		code := strings.TrimSpace(column[start:end])
+
+		if code == "" {
+			continue
+		}
+
+		occurrence, _ := LookupOccurrence(code)
+		occurrences = append(occurrences, occurrence)
+	}
+
+	return occurrences
+}
+
+// Rejected reports whether any occurrence refuses the record.
+func Rejected(occurrences []Occurrence) bool {
+	for _, occurrence := range occurrences {
+		if occurrence.Class == OccurrenceRejected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Occurrences decodes the return codes of the batch.
+func (b BillingBatchHeader) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
+
+// Occurrences decodes the return codes of the payment.
+func (b BillingSegmentA) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
+
+// Occurrences decodes the return code of the payment receipt.
+func (b BillingSegmentAReceipt) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Ocurrence)
+}
+
+// Occurrences decodes the return codes of the batch totals.
+func (b BillingBatchTrailer) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
+
+// Occurrences decodes the return codes of the batch.
+func (b BillingReturnBatchHeader) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
+
+// Occurrences decodes the return codes of the payment.
+func (b BillingReturnSegmentA) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
+
+// Occurrences decodes the return codes of the batch totals.
+func (b BillingReturnBatchTrailer) Occurrences() []Occurrence {
+	return ParseOccurrences(b.Occurrence)
+}
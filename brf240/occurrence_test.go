@@ -0,0 +1,64 @@
+package brf240_test
+
+import (
+	"testing"
+
+	"github.com/libercapital/document-translator-go/brf240"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOccurrences(t *testing.T) {
+	var tests = []struct {
+		name         string
+		column       string
+		wantCodes    []string
+		wantClasses  []brf240.OccurrenceClass
+		wantRejected bool
+	}{
+		{name: "blank column", column: "          "},
+		{name: "accepted payment", column: "00        ", wantCodes: []string{"00"}, wantClasses: []brf240.OccurrenceClass{brf240.OccurrenceAccepted}},
+		{
+			name:         "rejected payment with several codes",
+			column:       "ZAagAN",
+			wantCodes:    []string{"ZA", "AG", "AN"},
+			wantClasses:  []brf240.OccurrenceClass{brf240.OccurrenceInformational, brf240.OccurrenceRejected, brf240.OccurrenceRejected},
+			wantRejected: true,
+		},
+		{name: "bank specific code", column: "12", wantCodes: []string{"12"}, wantClasses: []brf240.OccurrenceClass{brf240.OccurrenceUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			occurrences := brf240.ParseOccurrences(tt.column)
+
+			var codes []string
+			var classes []brf240.OccurrenceClass
+			for _, occurrence := range occurrences {
+				codes = append(codes, occurrence.Code)
+				classes = append(classes, occurrence.Class)
+			}
+
+			assert.Equal(t, tt.wantCodes, codes)
+			assert.Equal(t, tt.wantClasses, classes)
+			assert.Equal(t, tt.wantRejected, brf240.Rejected(occurrences))
+		})
+	}
+}
+
+func TestLookupOccurrence(t *testing.T) {
+	occurrence, ok := brf240.LookupOccurrence("AG")
+	assert.True(t, ok)
+	assert.Equal(t, brf240.Occurrence{
+		Code:          "AG",
+		DescriptionPT: "Agência/Conta Corrente/DV Inválido",
+		DescriptionEN: "Invalid branch/checking account/check digit",
+		Class:         brf240.OccurrenceRejected,
+	}, occurrence)
+	assert.Equal(t, "rejected", occurrence.Class.String())
+
+	segment := brf240.BillingSegmentA{Occurrence: "BD"}
+	assert.Equal(t, "Successfully included", segment.Occurrences()[0].DescriptionEN)
+
+	_, ok = brf240.LookupOccurrence("99")
+	assert.False(t, ok)
+}
@@ -0,0 +1,122 @@
+package brf240
+
+import (
+	"fmt"
+	"reflect"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBRF240,
+		RecordLength: 240,
+		Kind:         recordKind,
+		Records: map[string]documenttranslator.Record{
+			"0":    BillingFileHeader{},
+			"1":    BillingBatchHeader{},
+			"3A":   BillingSegmentA{},
+			"3A12": BillingSegmentAReceipt{},
+			"3Y52": BillingSegmentY52{},
+			"5":    BillingBatchTrailer{},
+			"9":    BillingFileTrailer{},
+		},
+		Parse:    parseRecord,
+		Finalize: finalizeRemittance,
+	})
+
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatBRF240Return,
+		RecordLength: 240,
+		Kind:         returnRecordKind,
+		Records:      returnRecords,
+		Parse:        parseReturnRecord,
+		Finalize:     finalizeReturn,
+	})
+}
+
+// returnRecords holds a zero value of each record of a return document by its registry key.
+var returnRecords = map[string]documenttranslator.Record{
+	"0":  BillingReturnFileHeader{},
+	"1":  BillingReturnBatchHeader{},
+	"3A": BillingReturnSegmentA{},
+	"5":  BillingReturnBatchTrailer{},
+	"9":  BillingReturnFileTrailer{},
+}
+
+// recordKind returns the registry key of a line: its registry kind, followed by the segment
+// and the instruction or optional registry that tell segment A, receipt and Y52 apart.
+func recordKind(line string) (string, error) {
+	if len(line) <= optionalRegistryPosition+instructionLength {
+		return "", wraperrors.NewErrWrap(documenttranslator.ErrParseShorterThenDeliminator, fmt.Errorf("at line with %d characters", len(line)))
+	}
+
+	switch record := parseObjectFunc(line).(type) {
+	case *BillingFileHeader:
+		return "0", nil
+	case *BillingBatchHeader:
+		return "1", nil
+	case *BillingBatchTrailer:
+		return "5", nil
+	case *BillingFileTrailer:
+		return "9", nil
+	case *BillingSegmentAReceipt:
+		return "3A12", nil
+	case *BillingSegmentY52:
+		return "3Y52", nil
+	case *BillingSegmentA:
+		return "3A", nil
+	default:
+		return "", fmt.Errorf("%w: %T", documenttranslator.ErrUnknownRecordKind, record)
+	}
+}
+
+// returnRecordKind returns the registry key of a return document line: its registry kind,
+// followed by the segment for details.
+func returnRecordKind(line string) (string, error) {
+	if len(line) <= segmentPosition {
+		return "", wraperrors.NewErrWrap(documenttranslator.ErrParseShorterThenDeliminator, fmt.Errorf("at line with %d characters", len(line)))
+	}
+
+	kind := line[kindPosition : kindPosition+1]
+
+	if kind == "3" {
+		kind += line[segmentPosition : segmentPosition+1]
+	}
+
+	if _, ok := returnRecords[kind]; !ok {
+		return "", fmt.Errorf("%w: %q", documenttranslator.ErrUnknownRecordKind, kind)
+	}
+
+	return kind, nil
+}
+
+func parseReturnRecord(line string) (documenttranslator.Record, error) {
+	kind, err := returnRecordKind(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := parser.LineTo(line, func(line string) interface{} {
+		return reflect.New(reflect.TypeOf(returnRecords[kind])).Interface()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return record.(documenttranslator.Record), nil
+}
+
+func parseRecord(line string) (documenttranslator.Record, error) {
+	record, err := Parse(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return record.(documenttranslator.Record), nil
+}
@@ -0,0 +1,42 @@
+package brf240_test
+
+import (
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/brf240"
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestBillingRoundTripLines(t *testing.T) {
+	roundtrip.CheckLines(t, brf240.Parse,
+		"35300000         272493216000147003320500085000000650189370000005361516 BRF S/A                       Banco Santander                         20306201921310000589206006250                                                                     ",
+		"35300011C2003060 272493216000147003320500085000000650189370000005361516 BRF S/A                       TITULO DISPONIVEL PARA NEGOCIACAO       0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000          ",
+		"3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          ",
+		"BRF0001300001A112G10 TRANSPORTES LTDA                2           0756916100049200000000000000         732807     000085997-001-001180920200000000000000000000000000000000000000000000000000059454850255425BDBRFSA25005102375484201900112        ",
+		"2370001300001Y 00520000000000120510000000004431823009202341230900766315001035570200000120511001205197                                                                                                                                           ",
+		"35300015         035193000000040420170731000000000000000000                                                                                                                                                                                     ",
+		"35399999         000001035195                                                                                                                                                                                                                   ",
+	)
+}
+
+func TestBillingRoundTripRandomRecords(t *testing.T) {
+	records := []documenttranslator.Record{
+		brf240.BillingFileHeader{},
+		brf240.BillingBatchHeader{},
+		brf240.BillingSegmentA{},
+		brf240.BillingSegmentAReceipt{},
+		brf240.BillingSegmentY52{},
+		brf240.BillingBatchTrailer{},
+		brf240.BillingFileTrailer{},
+		brf240.BillingReturnFileHeader{},
+		brf240.BillingReturnBatchHeader{},
+		brf240.BillingReturnSegmentA{},
+		brf240.BillingReturnBatchTrailer{},
+		brf240.BillingReturnFileTrailer{},
+	}
+
+	for _, record := range records {
+		roundtrip.Check(t, record, 200, nil)
+	}
+}
@@ -0,0 +1,74 @@
+package brf240
+
+import (
+	"strconv"
+	"strings"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/checkdigit"
+)
+
+// ValidateRecord checks the agency and account check digits of the company account.
+func (b BillingFileHeader) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.BankCode, b.Agency, b.AgencyCd, b.Account, b.AccountCd, "")
+}
+
+// ValidateRecord checks the agency and account check digits of the company account.
+func (b BillingBatchHeader) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.BankCode, b.Agency, b.AgencyCd, b.Account, b.AccountCd, "")
+}
+
+// ValidateRecord checks the agency and account check digits of the vendor account.
+func (b BillingSegmentA) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.VendorBankCode, b.VendorAgency, b.VendorAgencyCd, b.VendorAccount, b.VendorAccountCd, "Vendor")
+}
+
+// ValidateRecord checks the agency and account check digits of the vendor account.
+func (b BillingSegmentAReceipt) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.VendorBankCode, b.VendorAgency, b.VendorAgencyCd, b.VendorAccount, b.VendorAccountCd, "Vendor")
+}
+
+// ValidateRecord checks the agency and account check digits of the company account.
+func (b BillingReturnFileHeader) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.BankCode, b.Agency, b.AgencyCd, b.Account, b.AccountCd, "")
+}
+
+// ValidateRecord checks the agency and account check digits of the company account.
+func (b BillingReturnBatchHeader) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(b.BankCode, b.Agency, b.AgencyCd, b.Account, b.AccountCd, "")
+}
+
+// ValidateRecord checks the agency and account check digits of the vendor account.
+func (b BillingReturnSegmentA) ValidateRecord() documenttranslator.ValidationErrors {
+	return bankAccountErrors(strconv.Itoa(b.VendorBankCode), strconv.Itoa(b.VendorAgency), b.VendorAgencyCd, b.VendorAccount, b.VendorAccountCd, "Vendor")
+}
+
+// bankAccountErrors validates informed check digits of the banks supported by checkdigit,
+// naming the rejected fields with prefix, e.g. "VendorAgencyCd". A digit is only rejected when
+// checkdigit computes a different one: agencies and accounts its rules can't handle, e.g.
+// accounts wider than the bank numbers them, are left unchecked.
+func bankAccountErrors(bankCode, agency, agencyCd, account, accountCd, prefix string) (validationErrors documenttranslator.ValidationErrors) {
+	if strings.Trim(agency, "0 ") == "" {
+		return nil
+	}
+
+	if strings.TrimSpace(agencyCd) != "" {
+		if expected, err := checkdigit.AgencyCheckDigit(bankCode, agency); err == nil && !sameDigit(expected, agencyCd) {
+			validationErrors = append(validationErrors, documenttranslator.FieldError{Field: prefix + "AgencyCd", Rule: "agencycd", Value: agencyCd})
+		}
+	}
+
+	if strings.Trim(account, "0 ") != "" && strings.TrimSpace(accountCd) != "" {
+		if expected, err := checkdigit.AccountCheckDigit(bankCode, agency, account); err == nil && !sameDigit(expected, accountCd) {
+			validationErrors = append(validationErrors, documenttranslator.FieldError{Field: prefix + "AccountCd", Rule: "accountcd", Value: accountCd})
+		}
+	}
+
+	return validationErrors
+}
+
+// sameDigit reports whether an informed digit is the expected one, any digit matching banks
+// whose numbers have no check digit.
+func sameDigit(expected, digit string) bool {
+	return expected == "" || strings.EqualFold(strings.TrimSpace(digit), expected)
+}
@@ -0,0 +1,239 @@
+package checkdigit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidAccount = errors.New("agency or account does not have a valid length or digits")
+
+const (
+	BankBancoDoBrasil = "001"
+	BankSantander     = "033"
+	BankBradesco      = "237"
+	BankItau          = "341"
+)
+
+// bankRule computes the agency and account check digits of a bank. A nil agency
+// function means the bank does not use an agency check digit.
+type bankRule struct {
+	agency  func(agency string) (string, error)
+	account func(agency, account string) (string, error)
+}
+
+var bankRules = map[string]bankRule{
+	BankBancoDoBrasil: {
+		agency: func(agency string) (string, error) {
+			return modulo11Digit(agency, 4, 9, "X")
+		},
+		account: func(agency, account string) (string, error) {
+			return modulo11Digit(account, 8, 9, "X")
+		},
+	},
+	BankSantander: {
+		account: func(agency, account string) (string, error) {
+			agency, err := padNumber(agency, 4)
+
+			if err != nil {
+				return "", err
+			}
+
+			account, err = padNumber(account, 8)
+
+			if err != nil {
+				return "", err
+			}
+
+			weights := []int{9, 7, 3, 1, 0, 0, 9, 7, 1, 3, 1, 9, 7, 3}
+			number := agency + "00" + account
+			sum := 0
+
+			for i, weight := range weights {
+				sum += int(number[i]-'0') * weight % 10
+			}
+
+			return strconv.Itoa((10 - sum%10) % 10), nil
+		},
+	},
+	BankBradesco: {
+		agency: func(agency string) (string, error) {
+			return modulo11Digit(agency, 4, 9, "P")
+		},
+		account: func(agency, account string) (string, error) {
+			return modulo11Digit(account, 7, 7, "P")
+		},
+	},
+	BankItau: {
+		account: func(agency, account string) (string, error) {
+			agency, err := padNumber(agency, 4)
+
+			if err != nil {
+				return "", err
+			}
+
+			account, err = padNumber(account, 5)
+
+			if err != nil {
+				return "", err
+			}
+
+			digit, err := Modulo10(agency + account)
+
+			return strconv.Itoa(digit), err
+		},
+	},
+}
+
+// Modulo10 computes the modulo 10 check digit of number, with weights 2 and 1 alternating
+// right to left and the digits of each product summed.
+func Modulo10(number string) (int, error) {
+	values, err := characterValues(number, false)
+
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0
+	weight := 2
+
+	for i := len(values) - 1; i >= 0; i-- {
+		product := values[i] * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// Modulo11 computes 11 minus the remainder of the division by 11 of number weighted
+// from 2 up to maxWeight, right to left. The result ranges from 1 to 11, leaving the
+// mapping of 10 and 11 to each rule.
+func Modulo11(number string, maxWeight int) (int, error) {
+	values, err := characterValues(number, false)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return 11 - weightedSum(values, maxWeight)%11, nil
+}
+
+// AgencyCheckDigit computes the agency check digit of a bank, returning an empty string
+// for banks whose agencies have no check digit.
+//
+// Parameters:
+//   - bank: The bank code, e.g. "237". Zeros padding it to a wider column are ignored.
+//   - agency: The agency number without its check digit.
+//
+// Returns:
+//   - string: The check digit.
+//   - error: ErrUnsupportedBank when the bank has no known rule or ErrInvalidAccount for malformed agencies.
+func AgencyCheckDigit(bank, agency string) (string, error) {
+	rule, err := ruleFor(bank)
+
+	if err != nil || rule.agency == nil {
+		return "", err
+	}
+
+	return rule.agency(agency)
+}
+
+// AccountCheckDigit computes the account check digit of a bank.
+//
+// Parameters:
+//   - bank: The bank code, e.g. "237". Zeros padding it to a wider column are ignored.
+//   - agency: The agency number without its check digit, used by banks whose rule includes it.
+//   - account: The account number without its check digit.
+//
+// Returns:
+//   - string: The check digit.
+//   - error: ErrUnsupportedBank when the bank has no known rule or ErrInvalidAccount for malformed numbers.
+func AccountCheckDigit(bank, agency, account string) (string, error) {
+	rule, err := ruleFor(bank)
+
+	if err != nil {
+		return "", err
+	}
+
+	return rule.account(agency, account)
+}
+
+// ValidAgency reports whether digit is the agency check digit of a bank. Any digit is
+// accepted for banks whose agencies have no check digit.
+func ValidAgency(bank, agency, digit string) (bool, error) {
+	expected, err := AgencyCheckDigit(bank, agency)
+
+	if errors.Is(err, ErrInvalidAccount) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return expected == "" || strings.ToUpper(strings.TrimSpace(digit)) == expected, nil
+}
+
+// ValidAccount reports whether digit is the account check digit of a bank.
+func ValidAccount(bank, agency, account, digit string) (bool, error) {
+	expected, err := AccountCheckDigit(bank, agency, account)
+
+	if errors.Is(err, ErrInvalidAccount) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return strings.ToUpper(strings.TrimSpace(digit)) == expected, nil
+}
+
+func ruleFor(bank string) (bankRule, error) {
+	code, err := padNumber(bank, 3)
+
+	if err != nil {
+		return bankRule{}, fmt.Errorf("%w: bank %q", ErrUnsupportedBank, bank)
+	}
+
+	rule, ok := bankRules[code]
+
+	if !ok {
+		return bankRule{}, fmt.Errorf("%w: bank %q", ErrUnsupportedBank, bank)
+	}
+
+	return rule, nil
+}
+
+// modulo11Digit computes a modulo 11 check digit, mapping 10 to tenDigit and 11 to "0".
+func modulo11Digit(number string, width int, maxWeight int, tenDigit string) (string, error) {
+	number, err := padNumber(number, width)
+
+	if err != nil {
+		return "", err
+	}
+
+	digit, _ := Modulo11(number, maxWeight)
+
+	switch digit {
+	case 10:
+		return tenDigit, nil
+	case 11:
+		return "0", nil
+	}
+
+	return strconv.Itoa(digit), nil
+}
+
+// padNumber left pads number with zeros to width, dropping zeros that pad it to a wider column.
+func padNumber(number string, width int) (string, error) {
+	number = strings.TrimLeft(strings.TrimSpace(number), "0")
+
+	if len(number) > width || strings.IndexFunc(number, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return "", fmt.Errorf("%w: %q does not fit %d digits", ErrInvalidAccount, number, width)
+	}
+
+	return strings.Repeat("0", width-len(number)) + number, nil
+}
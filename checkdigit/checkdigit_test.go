@@ -0,0 +1,149 @@
+package checkdigit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidDocuments(t *testing.T) {
+	var tests = []struct {
+		name     string
+		value    string
+		wantCPF  bool
+		wantCNPJ bool
+	}{
+		{name: "formatted CPF", value: "529.982.247-25", wantCPF: true},
+		{name: "CPF padded to a CNPJ column", value: "00052998224725", wantCPF: true},
+		{name: "CPF with wrong check digit", value: "529.982.247-24"},
+		{name: "CPF with repeated digits", value: "111.111.111-11"},
+		{name: "formatted CNPJ", value: "11.222.333/0001-81", wantCNPJ: true},
+		{name: "CNPJ padded to a wider column", value: "00011222333000181", wantCNPJ: true},
+		{name: "CNPJ with wrong check digit", value: "11222333000180"},
+		{name: "alphanumeric CNPJ", value: "12.ABC.345/01DE-35", wantCNPJ: true},
+		{name: "lowercase alphanumeric CNPJ", value: "12abc34501de35", wantCNPJ: true},
+		{name: "alphanumeric CNPJ with wrong check digit", value: "12ABC34501DE34"},
+		{name: "letters in check digits", value: "12ABC34501DEAB"},
+		{name: "letters in CPF", value: "5299822472A"},
+		{name: "blank", value: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCPF, ValidCPF(tt.value))
+			assert.Equal(t, tt.wantCNPJ, ValidCNPJ(tt.value))
+			assert.Equal(t, tt.wantCPF || tt.wantCNPJ, ValidDocument(tt.value))
+		})
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	digits, err := CPFCheckDigits("529982247")
+	assert.Nil(t, err)
+	assert.Equal(t, "25", digits)
+
+	digits, err = CNPJCheckDigits("12ABC34501DE")
+	assert.Nil(t, err)
+	assert.Equal(t, "35", digits)
+
+	_, err = CPFCheckDigits("5299822")
+	assert.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = CNPJCheckDigits("11.222.333/0001")
+	assert.ErrorIs(t, err, ErrInvalidDocument)
+}
+
+func TestFormatDocuments(t *testing.T) {
+	formatted, err := FormatCPF("00052998224725")
+	assert.Nil(t, err)
+	assert.Equal(t, "529.982.247-25", formatted)
+
+	formatted, err = FormatCNPJ("12abc34501de35")
+	assert.Nil(t, err)
+	assert.Equal(t, "12.ABC.345/01DE-35", formatted)
+
+	formatted, err = FormatDocument("000011222333000181")
+	assert.Nil(t, err)
+	assert.Equal(t, "11.222.333/0001-81", formatted)
+
+	_, err = FormatCPF("1234")
+	assert.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = FormatDocument("12345678900")
+	assert.ErrorIs(t, err, ErrInvalidDocument)
+}
+
+func TestModulo(t *testing.T) {
+	digit, err := Modulo10("005712345")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, digit)
+
+	digit, err = Modulo11("1425", 9)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, digit)
+
+	_, err = Modulo10("12A")
+	assert.ErrorIs(t, err, ErrInvalidDocument)
+}
+
+func TestBankCheckDigits(t *testing.T) {
+	var tests = []struct {
+		name        string
+		bank        string
+		agency      string
+		account     string
+		wantAgency  string
+		wantAccount string
+		wantErr     error
+	}{
+		{name: "Banco do Brasil", bank: "001", agency: "1234", account: "12345672", wantAgency: "3", wantAccount: "X"},
+		{name: "Santander", bank: "033", agency: "0189", account: "01017417", wantAgency: "", wantAccount: "9"},
+		{name: "Bradesco", bank: "237", agency: "1425", account: "0238069", wantAgency: "7", wantAccount: "2"},
+		{name: "Bradesco with account digit P", bank: "00237", agency: "00001", account: "0000006", wantAgency: "9", wantAccount: "P"},
+		{name: "Itaú", bank: "341", agency: "0057", account: "12345", wantAgency: "", wantAccount: "7"},
+		{name: "unsupported bank", bank: "999", agency: "1", account: "1", wantErr: ErrUnsupportedBank},
+		{name: "account wider than the bank rule", bank: "237", agency: "1425", account: "123456789", wantAgency: "7", wantErr: ErrInvalidAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agencyDigit, agencyErr := AgencyCheckDigit(tt.bank, tt.agency)
+			accountDigit, accountErr := AccountCheckDigit(tt.bank, tt.agency, tt.account)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, accountErr, tt.wantErr)
+				return
+			}
+
+			assert.Nil(t, agencyErr)
+			assert.Nil(t, accountErr)
+			assert.Equal(t, tt.wantAgency, agencyDigit)
+			assert.Equal(t, tt.wantAccount, accountDigit)
+		})
+	}
+}
+
+func TestValidBankDigits(t *testing.T) {
+	valid, err := ValidAgency("237", "1425", "7")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	valid, err = ValidAgency("341", "0057", " ")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	valid, err = ValidAccount("001", "1234", "12345672", "x")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	valid, err = ValidAccount("237", "1425", "0238069", "3")
+	assert.Nil(t, err)
+	assert.False(t, valid)
+
+	valid, err = ValidAccount("237", "1425", "ABC", "3")
+	assert.Nil(t, err)
+	assert.False(t, valid)
+
+	_, err = ValidAgency("104", "1", "1")
+	assert.ErrorIs(t, err, ErrUnsupportedBank)
+}
@@ -0,0 +1,199 @@
+package checkdigit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidDocument = errors.New("document does not have a valid length or characters")
+	ErrUnsupportedBank = errors.New("bank check digit rule not supported")
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+// ValidCPF reports whether value holds a CPF with valid check digits. Punctuation and
+// zeros padding the number to a wider column are ignored.
+func ValidCPF(value string) bool {
+	characters, ok := documentCharacters(value, cpfLength, false)
+
+	if !ok {
+		return false
+	}
+
+	checkDigits, err := CPFCheckDigits(characters[:cpfLength-2])
+
+	return err == nil && checkDigits == characters[cpfLength-2:]
+}
+
+// ValidCNPJ reports whether value holds a CNPJ with valid check digits, accepting the
+// alphanumeric CNPJ whose first twelve characters may be uppercase letters. Punctuation
+// and zeros padding the number to a wider column are ignored.
+func ValidCNPJ(value string) bool {
+	characters, ok := documentCharacters(value, cnpjLength, true)
+
+	if !ok {
+		return false
+	}
+
+	checkDigits, err := CNPJCheckDigits(characters[:cnpjLength-2])
+
+	return err == nil && checkDigits == characters[cnpjLength-2:]
+}
+
+// ValidDocument reports whether value holds either a valid CPF or a valid CNPJ.
+func ValidDocument(value string) bool {
+	return ValidCPF(value) || ValidCNPJ(value)
+}
+
+// CPFCheckDigits computes the two check digits of the nine digits base of a CPF.
+func CPFCheckDigits(base string) (string, error) {
+	values, err := characterValues(base, false)
+
+	if err != nil || len(values) != cpfLength-2 {
+		return "", fmt.Errorf("%w: CPF base %q", ErrInvalidDocument, base)
+	}
+
+	first := documentDigit(weightedSum(values, 10))
+	second := documentDigit(weightedSum(append(values, first), 11))
+
+	return fmt.Sprintf("%d%d", first, second), nil
+}
+
+// CNPJCheckDigits computes the two check digits of the twelve characters base of a CNPJ.
+// Letters are valued by their ASCII code minus 48, as defined for the alphanumeric CNPJ.
+func CNPJCheckDigits(base string) (string, error) {
+	values, err := characterValues(base, true)
+
+	if err != nil || len(values) != cnpjLength-2 {
+		return "", fmt.Errorf("%w: CNPJ base %q", ErrInvalidDocument, base)
+	}
+
+	first := documentDigit(weightedSum(values, 9))
+	second := documentDigit(weightedSum(append(values, first), 9))
+
+	return fmt.Sprintf("%d%d", first, second), nil
+}
+
+// FormatCPF formats a CPF as 000.000.000-00.
+//
+// Example:
+//
+//	formatted, err := FormatCPF("00052998224725") // "529.982.247-25"
+func FormatCPF(value string) (string, error) {
+	characters, ok := documentCharacters(value, cpfLength, false)
+
+	if !ok {
+		return "", fmt.Errorf("%w: CPF %q", ErrInvalidDocument, value)
+	}
+
+	return characters[0:3] + "." + characters[3:6] + "." + characters[6:9] + "-" + characters[9:11], nil
+}
+
+// FormatCNPJ formats a CNPJ as 00.000.000/0000-00.
+//
+// Example:
+//
+//	formatted, err := FormatCNPJ("11222333000181") // "11.222.333/0001-81"
+func FormatCNPJ(value string) (string, error) {
+	characters, ok := documentCharacters(value, cnpjLength, true)
+
+	if !ok {
+		return "", fmt.Errorf("%w: CNPJ %q", ErrInvalidDocument, value)
+	}
+
+	return characters[0:2] + "." + characters[2:5] + "." + characters[5:8] + "/" + characters[8:12] + "-" + characters[12:14], nil
+}
+
+// FormatDocument formats value as a CPF or as a CNPJ, whichever has valid check digits.
+func FormatDocument(value string) (string, error) {
+	if ValidCPF(value) {
+		return FormatCPF(value)
+	}
+
+	if ValidCNPJ(value) {
+		return FormatCNPJ(value)
+	}
+
+	return "", fmt.Errorf("%w: document %q", ErrInvalidDocument, value)
+}
+
+// documentCharacters strips punctuation from value and returns its last length characters,
+// which may only be preceded by zeros. Documents made of one repeated digit are rejected.
+func documentCharacters(value string, length int, alphanumeric bool) (string, bool) {
+	var characters strings.Builder
+
+	for _, r := range strings.ToUpper(value) {
+		switch {
+		case r >= '0' && r <= '9', alphanumeric && r >= 'A' && r <= 'Z':
+			characters.WriteRune(r)
+		case strings.ContainsRune(".-/ ", r):
+		default:
+			return "", false
+		}
+	}
+
+	document := characters.String()
+
+	if len(document) < length || strings.Trim(document[:len(document)-length], "0") != "" {
+		return "", false
+	}
+
+	document = document[len(document)-length:]
+
+	// Repeated digits such as 111.111.111-11 satisfy the check digits but are not valid documents.
+	if strings.Count(document, document[:1]) == length {
+		return "", false
+	}
+
+	// Only the base of an alphanumeric CNPJ may hold letters.
+	if strings.IndexFunc(document[length-2:], func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return "", false
+	}
+
+	return document, true
+}
+
+func characterValues(value string, alphanumeric bool) ([]int, error) {
+	var values []int
+
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9', alphanumeric && r >= 'A' && r <= 'Z':
+			values = append(values, int(r-'0'))
+		default:
+			return nil, fmt.Errorf("%w: invalid character %q", ErrInvalidDocument, r)
+		}
+	}
+
+	return values, nil
+}
+
+// weightedSum multiplies values by weights growing from 2 up to maxWeight, right to left.
+func weightedSum(values []int, maxWeight int) int {
+	sum := 0
+	weight := 2
+
+	for i := len(values) - 1; i >= 0; i-- {
+		sum += values[i] * weight
+
+		if weight++; weight > maxWeight {
+			weight = 2
+		}
+	}
+
+	return sum
+}
+
+// documentDigit maps a modulo 11 weighted sum to a CPF or CNPJ check digit.
+func documentDigit(sum int) int {
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+
+	return 0
+}
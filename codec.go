@@ -0,0 +1,14 @@
+package documenttranslator
+
+// FieldUnmarshaler is implemented by field types that decode themselves from
+// the raw columns of a fixed-width line. data holds the columns untrimmed.
+type FieldUnmarshaler interface {
+	UnmarshalFixed(data []byte) error
+}
+
+// FieldMarshaler is implemented by field types that encode themselves into the
+// columns of a fixed-width line. The returned data must not be wider than width;
+// shorter data is completed with spaces on the right.
+type FieldMarshaler interface {
+	MarshalFixed(width int) ([]byte, error)
+}
@@ -0,0 +1,179 @@
+package documenttranslator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidDefault = errors.New("default value does not convert to its field type")
+
+// FillDefaults assigns the fields of a record left with their zero value, descending into
+// nested structs. A field takes the value of its `default` translator option, e.g.
+// `translator:"part:7..7;default:3"`, or else of its kind or segment option, so records built
+// in code or imported from JSON need not repeat the constants of the layout.
+//
+// Parameters:
+//   - record: A pointer to the record.
+//
+// Returns:
+//   - error: ErrInvalidDefault when a default value does not convert to its field type.
+//
+// Example:
+//
+//	segment := brf240.BillingReturnSegmentA{BatchSequentialNumber: 1}
+//	if err := FillDefaults(&segment); err != nil {
+//	    // Handle the error
+//	}
+func FillDefaults(record interface{}) error {
+	value := reflect.ValueOf(record)
+
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a pointer to a struct", ErrInvalidDefault, record)
+	}
+
+	return fillStructDefaults(value.Elem())
+}
+
+func fillStructDefaults(structValue reflect.Value) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		options := tagOptions(tag)
+		fieldValue := structValue.Field(i)
+
+		if _, isPart := options["part"]; !isPart && fieldValue.Kind() == reflect.Struct {
+			if err := fillStructDefaults(fieldValue); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !fieldValue.IsZero() {
+			continue
+		}
+
+		text, ok := options["default"]
+
+		if !ok {
+			text, ok = options["kind"]
+		}
+
+		if !ok {
+			text, ok = options["segment"]
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := setDefault(fieldValue, text, options["timeParse"]); err != nil {
+			return fmt.Errorf("%w: field %s default %q: %v", ErrInvalidDefault, field.Name, text, err)
+		}
+	}
+
+	return nil
+}
+
+// setDefault converts the text of a default to the type of a field. Times are read with
+// the timeParse layout of the field.
+func setDefault(field reflect.Value, text string, layout string) error {
+	switch field.Interface().(type) {
+	case time.Time:
+		moment, err := time.Parse(layout, text)
+
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(moment))
+
+		return nil
+	case decimal.Decimal:
+		number, err := decimal.NewFromString(text)
+
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(number))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(text, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(number)
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(text)
+
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(boolean)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+// layoutTag returns the translator tag of an exported field of a record layout. Embedded structs
+// need no tag: they are blocks of columns shared by several records, whose fields are promoted.
+func layoutTag(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	if tag, ok := field.Tag.Lookup("translator"); ok {
+		return tag, true
+	}
+
+	return "", field.Anonymous && field.Type.Kind() == reflect.Struct
+}
+
+// embeddedBlock reports whether a field is an embedded struct of columns, exported and
+// imported as if its fields belonged to the record holding it.
+func embeddedBlock(field reflect.StructField, tag string) bool {
+	_, isPart := tagOptions(tag)["part"]
+
+	return field.Anonymous && !isPart && field.Type.Kind() == reflect.Struct
+}
+
+// tagOptions splits a translator tag into its options and their values.
+func tagOptions(tag string) map[string]string {
+	options := map[string]string{}
+
+	for _, option := range strings.Split(tag, ";") {
+		keyValuePair := strings.SplitN(option, ":", 2)
+		options[keyValuePair[0]] = ""
+
+		if len(keyValuePair) > 1 {
+			options[keyValuePair[0]] = keyValuePair[1]
+		}
+	}
+
+	return options
+}
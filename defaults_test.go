@@ -0,0 +1,52 @@
+package documenttranslator_test
+
+import (
+	"testing"
+	"time"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type defaultsNested struct {
+	Code string `translator:"part:0..2;default:000"`
+}
+
+type defaultsRecord struct {
+	Kind     int             `translator:"part:0..0;kind:3"`
+	Segment  string          `translator:"part:1..1;segment:A"`
+	Version  string          `translator:"part:2..4;default:060"`
+	Density  int             `translator:"part:5..8;default:6250"`
+	Rate     decimal.Decimal `translator:"part:9..14;precision:4;default:0.0025"`
+	Date     time.Time       `translator:"part:15..22;timeParse:02012006;default:01012000"`
+	Kept     int             `translator:"part:23..24;default:10"`
+	Return   defaultsNested  `translator:"offset:25"`
+	Untagged string
+}
+
+func TestFillDefaults(t *testing.T) {
+	record := defaultsRecord{Kept: 7}
+
+	assert.NoError(t, documenttranslator.FillDefaults(&record))
+	assert.Equal(t, defaultsRecord{
+		Kind:    3,
+		Segment: "A",
+		Version: "060",
+		Density: 6250,
+		Rate:    decimal.RequireFromString("0.0025"),
+		Date:    time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Kept:    7,
+		Return:  defaultsNested{Code: "000"},
+	}, record)
+}
+
+func TestFillDefaultsErrors(t *testing.T) {
+	var invalid struct {
+		Density int `translator:"part:0..3;default:high"`
+	}
+
+	assert.ErrorIs(t, documenttranslator.FillDefaults(&invalid), documenttranslator.ErrInvalidDefault)
+	assert.ErrorIs(t, documenttranslator.FillDefaults(defaultsRecord{}), documenttranslator.ErrInvalidDefault)
+}
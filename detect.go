@@ -0,0 +1,195 @@
+package documenttranslator
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrUnknownFormat = errors.New("file does not match any known format")
+
+// Format identifies a file layout by the name of the package translating it.
+type Format string
+
+const (
+	FormatUnknown        Format = ""
+	FormatBradesco226    Format = "bradesco226"
+	FormatBradesco80     Format = "bradesco80"
+	FormatBradesco600    Format = "bradesco600"
+	FormatBradescoRating Format = "bradescorating"
+	FormatBRF240         Format = "brf240"
+	FormatBRF240Return   Format = "brf240return" // FormatBRF240Return is never detected, its lines look like FormatBRF240.
+	FormatGetnetExtrato  Format = "getnetextrato"
+)
+
+const (
+	detectSampleLines   = 100 // detectSampleLines is the number of lines read to detect a format.
+	detectMinConfidence = 0.6 // detectMinConfidence is the lowest confidence accepted as a match.
+
+	lengthWeight = 0.5 // lengthWeight is the share of the confidence given by line lengths.
+	headerWeight = 0.2 // headerWeight is the share of the confidence given by the first line markers.
+	recordWeight = 0.3 // recordWeight is the share of the confidence given by the markers of every line.
+)
+
+// Detection is the format of a file and how confident the detection is, from 0 to 1.
+type Detection struct {
+	Format     Format
+	Confidence float64
+}
+
+// formatSignature describes how the lines of a format look: their length, the markers of
+// the first line and the markers every line must have.
+type formatSignature struct {
+	format Format
+	length int
+	header func(line string) bool
+	record func(line string) bool
+}
+
+var formatSignatures = []formatSignature{
+	{
+		format: FormatBradesco226,
+		length: 226,
+		header: func(line string) bool { return column(line, 0, 1) == "1" && isDate(column(line, 10, 18)) },
+		record: func(line string) bool { return isOneOf(column(line, 0, 1), "1234") },
+	},
+	{
+		format: FormatBradesco80,
+		length: 80,
+		header: func(line string) bool { return column(line, 0, 1) == "0" && isDate(column(line, 1, 9)) },
+		record: func(line string) bool { return isDigits(column(line, 0, 1)) },
+	},
+	{
+		format: FormatBradesco600,
+		length: 713,
+		header: func(line string) bool { return isDate(column(line, 0, 8)) },
+		record: func(line string) bool { return isDate(column(line, 0, 8)) && isDigits(column(line, 8, 25)) },
+	},
+	{
+		format: FormatBradescoRating,
+		length: 30,
+		header: func(line string) bool { return isDigits(line) },
+		record: func(line string) bool { return isDigits(line) },
+	},
+	{
+		format: FormatBRF240,
+		length: 240,
+		header: func(line string) bool { return column(line, 3, 8) == "00000" },
+		record: func(line string) bool { return isDigits(column(line, 3, 7)) && isOneOf(column(line, 7, 8), "01359") },
+	},
+	{
+		format: FormatGetnetExtrato,
+		length: 400,
+		header: func(line string) bool {
+			return column(line, 0, 1) == "0" && strings.HasPrefix(column(line, 23, 31), "CEADM")
+		},
+		record: func(line string) bool { return isOneOf(column(line, 0, 1), "0123569") },
+	},
+}
+
+// Detect sniffs the first lines of a file and returns the format they match best, scored
+// by line length, the record markers of the first line (e.g. the getnet CEADM100 version
+// or the brf240 file header kind at position 8) and the record kind of every line.
+//
+// Parameters:
+//   - reader: The file contents. Detect reads up to its first 100 lines.
+//
+// Returns:
+//   - Detection: The best matching format and its confidence.
+//   - error: ErrUnknownFormat when no format reaches a confidence of 0.6, or a read error.
+//
+// Example:
+//
+//	file, _ := os.Open("remessa.txt")
+//	detection, err := Detect(file)
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	fmt.Println(detection.Format, detection.Confidence)
+func Detect(reader io.Reader) (Detection, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(reader)
+
+	for len(lines) < detectSampleLines && scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Detection{}, err
+	}
+
+	var best Detection
+
+	for _, signature := range formatSignatures {
+		if confidence := signature.score(lines); confidence > best.Confidence {
+			best = Detection{Format: signature.format, Confidence: confidence}
+		}
+	}
+
+	if best.Confidence < detectMinConfidence {
+		return Detection{}, ErrUnknownFormat
+	}
+
+	return best, nil
+}
+
+// score weighs how many lines have the format length and markers. Lines without a single
+// record marker score 0, whatever their length and first line.
+func (s formatSignature) score(lines []string) float64 {
+	if len(lines) == 0 {
+		return 0
+	}
+
+	var lengths, records int
+
+	for _, line := range lines {
+		if len(line) == s.length {
+			lengths++
+		}
+
+		if s.record(line) {
+			records++
+		}
+	}
+
+	if records == 0 {
+		return 0
+	}
+
+	confidence := lengthWeight*float64(lengths)/float64(len(lines)) + recordWeight*float64(records)/float64(len(lines))
+
+	if s.header(lines[0]) {
+		confidence += headerWeight
+	}
+
+	return confidence
+}
+
+// column returns the characters from start up to end, or an empty string for lines too short to hold them.
+func column(line string, start, end int) string {
+	if len(line) < end {
+		return ""
+	}
+
+	return line[start:end]
+}
+
+// isOneOf reports whether character is one of the characters of set.
+func isOneOf(character string, set string) bool {
+	return len(character) == 1 && strings.Contains(set, character)
+}
+
+func isDigits(text string) bool {
+	return text != "" && strings.Trim(text, "0123456789") == ""
+}
+
+func isDate(text string) bool {
+	_, err := time.Parse("02012006", text)
+
+	return err == nil
+}
@@ -0,0 +1,133 @@
+package documenttranslator
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	pad := func(line string, length int) string {
+		return line + strings.Repeat(" ", length-len(line))
+	}
+
+	random := rand.New(rand.NewSource(1))
+	randomLine := func(length int) string {
+		const characters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ "
+
+		line := make([]byte, length)
+
+		for i := range line {
+			line[i] = characters[random.Intn(len(characters))]
+		}
+
+		return string(line)
+	}
+
+	tests := []struct {
+		name     string
+		lines    []string
+		expected Detection
+		wantErr  error
+	}{
+		{
+			name: "brf240 remittance",
+			lines: []string{
+				pad("35300000         272493216000147003320500085000000650189370000005361516 BRF S/A", 240),
+				pad("35300011C2003060 272493216000147003320500085000000650189370000005361516 BRF S/A", 240),
+				pad("3530001300001A000FORNECEDOR 1", 240),
+				pad("35300015         035193000000040420170731000000000000000000", 240),
+				pad("35399999         000001035195", 240),
+			},
+			expected: Detection{Format: FormatBRF240, Confidence: 1},
+		},
+		{
+			name: "getnet statement",
+			lines: []string{
+				pad("01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.10.1 400 BYTES", 400),
+				pad("21013903        00052459700000200009816082024104422650921******1796", 400),
+				pad("9000000047", 400),
+			},
+			expected: Detection{Format: FormatGetnetExtrato, Confidence: 1},
+		},
+		{
+			name: "bradesco226 contracts",
+			lines: []string{
+				pad("1000012345160120240000000010000000010000000050000001", 226),
+				pad("2000012345", 226),
+				pad("3000012345", 226),
+				pad("4000012345", 226),
+			},
+			expected: Detection{Format: FormatBradesco226, Confidence: 1},
+		},
+		{
+			name: "bradesco80 settlements",
+			lines: []string{
+				pad("016012024BANCO BRADESCO", 80),
+				pad("1BDN000000001000012345", 80),
+				pad("9000002", 80),
+			},
+			expected: Detection{Format: FormatBradesco80, Confidence: 1},
+		},
+		{
+			name: "bradesco600 credit assessments",
+			lines: []string{
+				pad("1501202400000000000117304ELETROZEMA S/A", 713),
+				pad("1501202400000000000117305ELETROZEMA S/A", 713),
+			},
+			expected: Detection{Format: FormatBradesco600, Confidence: 1},
+		},
+		{
+			name: "rating",
+			lines: []string{
+				"000529982247250000000000123456",
+				"011222333000181000000000654321",
+			},
+			expected: Detection{Format: FormatBradescoRating, Confidence: 1},
+		},
+		{
+			name: "brf240 with a line out of length",
+			lines: []string{
+				pad("35300000         272493216000147003320500085000000650189370000005361516 BRF S/A", 240),
+				pad("3530001300001A000FORNECEDOR 1", 242),
+			},
+			expected: Detection{Format: FormatBRF240, Confidence: 0.75},
+		},
+		{
+			name:    "lines of the brf240 length without its markers",
+			lines:   []string{randomLine(240), randomLine(240), randomLine(240), randomLine(240)},
+			wantErr: ErrUnknownFormat,
+		},
+		{
+			name:    "lines of the bradesco600 length starting with a date without its markers",
+			lines:   []string{"15012024" + randomLine(705), "15012024" + randomLine(705)},
+			wantErr: ErrUnknownFormat,
+		},
+		{
+			name:    "unknown layout",
+			lines:   []string{"name,amount", "ACME,10.00"},
+			wantErr: ErrUnknownFormat,
+		},
+		{
+			name:    "empty file",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detection, err := Detect(strings.NewReader(strings.Join(tt.lines, "\r\n")))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.Format, detection.Format)
+			assert.InDelta(t, tt.expected.Confidence, detection.Confidence, 0.001)
+		})
+	}
+}
@@ -9,4 +9,8 @@ var (
 	ErrKindInconsistency           = errors.New("row invalid due kind inconsistency")
 	ErrSegmentInconsistency        = errors.New("row invalid due segment inconsistency")
 	ErrSegmentMustBeString         = errors.New("segment must be string")
+	ErrInvalidBoolToken            = errors.New("value does not match boolean tokens")
+	ErrFieldOverflow               = errors.New("marshaled field is wider than its columns")
+	ErrTooManyOccurrences          = errors.New("slice has more elements than its occurs")
+	ErrIrreversibleField           = errors.New("derived field does not read back from its written columns")
 )
@@ -0,0 +1,153 @@
+package getnetextrato
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var (
+	ErrUnknownLayoutVersion = errors.New("unknown getnet layout version")
+	ErrUnknownRegisterType  = errors.New("register type not supported by layout version")
+	ErrMisplacedRegister    = errors.New("register out of the header, registers and trailer order")
+	ErrMissingHeader        = errors.New("register found before the file header declaring its layout")
+)
+
+// LayoutVersion identifies a layout revision as declared in the file header.
+type LayoutVersion struct {
+	VersaoArquivo string // VersaoArquivo is the file version, e.g. "CEADM100".
+	VersaoLayout  string // VersaoLayout is the layout description, e.g. "SANT. V.10.1 400 BYTES".
+}
+
+func (v LayoutVersion) String() string {
+	return fmt.Sprintf("%s/%s", v.VersaoArquivo, v.VersaoLayout)
+}
+
+func (v LayoutVersion) normalize() LayoutVersion {
+	return LayoutVersion{
+		VersaoArquivo: strings.ToUpper(strings.TrimSpace(v.VersaoArquivo)),
+		VersaoLayout:  strings.ToUpper(strings.Join(strings.Fields(v.VersaoLayout), " ")),
+	}
+}
+
+// Layout describes how every register type of a layout version must be parsed.
+type Layout struct {
+	Version LayoutVersion                               // Version is the header identification of the layout.
+	Records map[RegisterType]parser.ParseObjectFunction // Records holds the target struct for each register type.
+}
+
+// Parse parses a line using the struct registered for its register type.
+func (l Layout) Parse(line string) (interface{}, error) {
+	kind, err := Kind(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parseObjectFunc, ok := l.Records[kind]
+
+	if !ok {
+		return nil, wraperrors.NewErrWrap(ErrUnknownRegisterType, fmt.Errorf("register type %s at layout %s", kind, l.Version))
+	}
+
+	return parser.LineTo(line, parseObjectFunc)
+}
+
+var (
+	layoutsMutex sync.RWMutex
+	layouts      = map[LayoutVersion]Layout{}
+)
+
+// RegisterLayout makes a layout version available for automatic selection,
+// replacing any layout previously registered for the same version.
+func RegisterLayout(layout Layout) {
+	layoutsMutex.Lock()
+	defer layoutsMutex.Unlock()
+
+	layouts[layout.Version.normalize()] = layout
+}
+
+// Layouts returns every registered layout version.
+func Layouts() []LayoutVersion {
+	layoutsMutex.RLock()
+	defer layoutsMutex.RUnlock()
+
+	var versions []LayoutVersion
+
+	for _, layout := range layouts {
+		versions = append(versions, layout.Version)
+	}
+
+	return versions
+}
+
+// LayoutFor returns the layout declared by a parsed header.
+func LayoutFor(header Header) (Layout, error) {
+	version := LayoutVersion{VersaoArquivo: header.VersaoArquivo, VersaoLayout: header.VersaoLayout}
+
+	layoutsMutex.RLock()
+	defer layoutsMutex.RUnlock()
+
+	layout, ok := layouts[version.normalize()]
+
+	if !ok {
+		return Layout{}, wraperrors.NewErrWrap(ErrUnknownLayoutVersion, fmt.Errorf("version %s", version))
+	}
+
+	return layout, nil
+}
+
+// DetectLayout parses the header line of a file and returns the layout it declares.
+func DetectLayout(headerLine string) (Layout, error) {
+	header, err := ParseHeader(headerLine)
+
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return LayoutFor(header)
+}
+
+// ReadFile parses every line of a file, skipping blank lines, through the layout declared by
+// the last header read. It reads the file as documenttranslator.ParseDocument does for
+// documenttranslator.FormatGetnetExtrato.
+func ReadFile(r io.Reader) (records []interface{}, err error) {
+	document, err := documenttranslator.ParseDocument(documenttranslator.FormatGetnetExtrato, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range document.Records {
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// LayoutV10_1 is the Santander/Getnet "SANT. V.10.1 400 BYTES" layout.
+var LayoutV10_1 = Layout{
+	Version: LayoutVersion{
+		VersaoArquivo: "CEADM100",
+		VersaoLayout:  "SANT. V.10.1 400 BYTES",
+	},
+	Records: map[RegisterType]parser.ParseObjectFunction{
+		TipoRegistroHeader:                func(string) interface{} { return new(Header) },
+		TipoRegistroResumoTransacional:    func(string) interface{} { return new(ResumoTransacional) },
+		TipoRegistroAnaliticoTransacional: func(string) interface{} { return new(AnaliticoTransacional) },
+		TipoRegistroAjusteFinanceiro:      func(string) interface{} { return new(AjusteFinanceiro) },
+		TipoRegistroResumoFinanceiro:      func(string) interface{} { return new(ResumoFinanceiro) },
+		TipoRegistroDetalheFinanceiro:     func(string) interface{} { return new(DetalheFinanceiro) },
+		TipoRegistroTrailer:               func(string) interface{} { return new(Trailer) },
+	},
+}
+
+func init() {
+	RegisterLayout(LayoutV10_1)
+}
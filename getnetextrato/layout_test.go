@@ -0,0 +1,102 @@
+package getnetextrato
+
+import (
+	"strings"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLayout(t *testing.T) {
+	cnabLine := "01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.10.1 400 BYTES                                                                                                                                                                                                                                                                                                "
+
+	layout, err := DetectLayout(cnabLine)
+
+	assert.NoError(t, err)
+	assert.Equal(t, LayoutV10_1.Version, layout.Version)
+}
+
+func TestDetectLayoutUnknownVersion(t *testing.T) {
+	cnabLine := "01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.11.0 400 BYTES                                                                                                                                                                                                                                                                                                "
+
+	_, err := DetectLayout(cnabLine)
+
+	assert.ErrorIs(t, err, ErrUnknownLayoutVersion)
+	assert.Contains(t, err.Error(), "SANT. V.11.0 400 BYTES")
+}
+
+func TestLayoutParseUnknownRegisterType(t *testing.T) {
+	layout := Layout{Version: LayoutVersion{VersaoArquivo: "CEADM099"}}
+
+	_, err := layout.Parse("9000000047")
+
+	assert.ErrorIs(t, err, ErrUnknownRegisterType)
+}
+
+func TestReadFile(t *testing.T) {
+	file := strings.Join([]string{
+		"01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.10.1 400 BYTES                                                                                                                                                                                                                                                                                                ",
+		"",
+		"9000000047                                                                                                                                                                                                                                                                                                                                                                                                      ",
+	}, "\r\n")
+
+	records, err := ReadFile(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.IsType(t, Header{}, records[0])
+	assert.Equal(t, 47, records[1].(Trailer).QuantidadeRegistros)
+
+	document, err := documenttranslator.ParseDocument(documenttranslator.FormatGetnetExtrato, strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{document.Records[0], document.Records[1]}, records)
+}
+
+func TestReadFileRegisterBeforeHeader(t *testing.T) {
+	trailer := "9000000047" + strings.Repeat(" ", 390)
+
+	_, err := ReadFile(strings.NewReader(trailer))
+
+	assert.ErrorIs(t, err, ErrMissingHeader)
+	assert.ErrorContains(t, err, "at line 1")
+}
+
+func TestParseDocumentDispatchesThroughHeaderLayout(t *testing.T) {
+	header := "01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.10.1 400 BYTES                                                                                                                                                                                                                                                                                                "
+	trailer := "9000000047                                                                                                                                                                                                                                                                                                                                                                                                      "
+
+	RegisterLayout(Layout{
+		Version: LayoutVersion{VersaoArquivo: "CEADM100", VersaoLayout: "SANT. V.99.0 400 BYTES"},
+		Records: map[RegisterType]parser.ParseObjectFunction{
+			TipoRegistroHeader: func(string) interface{} { return new(Header) },
+		},
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "registered layout", header: header},
+		{name: "layout without the register type", header: strings.Replace(header, "V.10.1", "V.99.0", 1), err: ErrUnknownRegisterType},
+		{name: "unknown layout", header: strings.Replace(header, "V.10.1", "V.11.0", 1), err: ErrUnknownLayoutVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			document, err := documenttranslator.ParseDocument(documenttranslator.FormatGetnetExtrato, strings.NewReader(test.header+"\r\n"+trailer))
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, document.Records, 2)
+			assert.Equal(t, 47, document.Records[1].(Trailer).QuantidadeRegistros)
+		})
+	}
+}
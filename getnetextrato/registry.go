@@ -0,0 +1,112 @@
+package getnetextrato
+
+import (
+	"fmt"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+func init() {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name:         documenttranslator.FormatGetnetExtrato,
+		RecordLength: 400,
+		Kind: func(line string) (string, error) {
+			kind, err := Kind(line)
+
+			return string(kind), err
+		},
+		Records: map[string]documenttranslator.Record{
+			string(TipoRegistroHeader):                Header{},
+			string(TipoRegistroResumoTransacional):    ResumoTransacional{},
+			string(TipoRegistroAnaliticoTransacional): AnaliticoTransacional{},
+			string(TipoRegistroAjusteFinanceiro):      AjusteFinanceiro{},
+			string(TipoRegistroResumoFinanceiro):      ResumoFinanceiro{},
+			string(TipoRegistroDetalheFinanceiro):     DetalheFinanceiro{},
+			string(TipoRegistroTrailer):               Trailer{},
+		},
+		Parse: func(line string) (documenttranslator.Record, error) {
+			return parseRecord(LayoutV10_1, line)
+		},
+		NewParser: newParser,
+		Finalize:  finalize,
+	})
+}
+
+// finalize completes an imported extrato with its trailer, counting every register of the
+// file, header and trailer included. A trailer ending the records is kept with its count
+// recomputed.
+func finalize(records []documenttranslator.Record) ([]documenttranslator.Record, error) {
+	if len(records) == 0 {
+		return nil, ErrMisplacedRegister
+	}
+
+	if _, ok := records[0].(Header); !ok {
+		return nil, wraperrors.NewErrWrap(ErrMisplacedRegister, fmt.Errorf("at record 1"))
+	}
+
+	trailer := Trailer{TipoRegistro: string(TipoRegistroTrailer)}
+
+	if last, ok := records[len(records)-1].(Trailer); ok {
+		trailer = last
+		records = records[:len(records)-1]
+	}
+
+	for i, record := range records {
+		if _, ok := record.(Trailer); ok {
+			return nil, wraperrors.NewErrWrap(ErrMisplacedRegister, fmt.Errorf("at record %d", i+1))
+		}
+	}
+
+	trailer.QuantidadeRegistros = len(records) + 1
+
+	return append(records, trailer), nil
+}
+
+// newParser returns a parser for the lines of a document, dispatching each line through the
+// layout declared by the last header read. Lines before the first header are refused with
+// ErrMissingHeader, as no layout is known for them.
+func newParser() func(line string) (documenttranslator.Record, error) {
+	var layout *Layout
+
+	return func(line string) (documenttranslator.Record, error) {
+		kind, err := Kind(line)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == TipoRegistroHeader {
+			detected, err := DetectLayout(line)
+
+			if err != nil {
+				return nil, err
+			}
+
+			layout = &detected
+		}
+
+		if layout == nil {
+			return nil, wraperrors.NewErrWrap(ErrMissingHeader, fmt.Errorf("register type %s", kind))
+		}
+
+		return parseRecord(*layout, line)
+	}
+}
+
+// parseRecord parses a line through a layout, as a record of the registry.
+func parseRecord(layout Layout, line string) (documenttranslator.Record, error) {
+	parsed, err := layout.Parse(line)
+
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := parsed.(documenttranslator.Record)
+
+	if !ok {
+		return nil, wraperrors.NewErrWrap(ErrUnknownRegisterType, fmt.Errorf("register type %s at layout %s is not a record", line[:1], layout.Version))
+	}
+
+	return record, nil
+}
@@ -0,0 +1,24 @@
+package getnetextrato
+
+import (
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/roundtrip"
+)
+
+func TestRoundTripRandomRecords(t *testing.T) {
+	records := []documenttranslator.Record{
+		Header{},
+		ResumoTransacional{},
+		AnaliticoTransacional{},
+		AjusteFinanceiro{},
+		ResumoFinanceiro{},
+		DetalheFinanceiro{},
+		Trailer{},
+	}
+
+	for _, record := range records {
+		roundtrip.Check(t, record, 200, nil)
+	}
+}
@@ -2,6 +2,7 @@ package getnetextrato
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ func TestParseHeader(t *testing.T) {
 	assert.Equal(t, "0", parsed.TipoRegistro)
 	assert.Equal(t, "16092024", parsed.DataCriacaoArquivo.Format("02012006"))
 	assert.Equal(t, "071329", parsed.HoraCriacaoArquivo)
+	assert.Equal(t, "2024-09-16T07:13:29-03:00", parsed.DataHoraCriacaoArquivo.Format(time.RFC3339))
 	assert.Equal(t, "16092024", parsed.DataMovimento.Format("02012006"))
 	assert.Equal(t, "CEADM100", parsed.VersaoArquivo)
 	assert.Equal(t, "1013903", parsed.CodigoEstabelecimento)
@@ -99,6 +101,7 @@ func TestParseAnaliticoTransacional(t *testing.T) {
 	assert.Equal(t, "000002000098", parsed.NSUAdquirente)
 	assert.Equal(t, "16082024", parsed.DataTransacao.Format("02012006"))
 	assert.Equal(t, "104422", parsed.HoraTransacao)
+	assert.Equal(t, "2024-08-16T10:44:22-03:00", parsed.DataHoraTransacao.Format(time.RFC3339))
 	assert.Equal(t, "650921******1796", parsed.NumeroCartao)
 	valorTransacao, _ := decimal.NewFromString("24142.39")
 	assert.Equal(t, valorTransacao, parsed.ValorTransacao)
@@ -358,3 +361,16 @@ func TestKindInvalid(t *testing.T) {
 	assert.EqualError(t, err, "invalid register type")
 	assert.Equal(t, RegisterType(""), kind)
 }
+
+func TestWriteHeaderCombinedOrDeprecatedDateTime(t *testing.T) {
+	location, _ := time.LoadLocation("America/Sao_Paulo")
+
+	combined, err := Header{TipoRegistro: "0", DataHoraCriacaoArquivo: time.Date(2024, time.September, 16, 7, 13, 29, 0, location)}.String()
+	assert.NoError(t, err)
+
+	deprecated, err := Header{TipoRegistro: "0", DataCriacaoArquivo: time.Date(2024, time.September, 16, 0, 0, 0, 0, time.UTC), HoraCriacaoArquivo: "071329"}.String()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "016092024071329", combined[:15])
+	assert.Equal(t, combined, deprecated)
+}
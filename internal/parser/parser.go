@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"unicode"
 
 	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/internal/utils"
+	"github.com/libercapital/document-translator-go/internal/validator"
 	"github.com/libercapital/document-translator-go/internal/wraperrors"
 	"github.com/shopspring/decimal"
 	"golang.org/x/text/runes"
@@ -31,13 +34,18 @@ type ParseOpt struct {
 }
 
 type ParseParams struct {
-	Deliminator   []int    // Deliminator holds the delimiter indices for the field.
-	TimeParse     string   // TimeParse specifies the time parsing format for the field.
-	Precision     int      // Precision specifies the decimal precision for the field.
-	PrefixFrom    []string // PrefixFrom specifies the prefix strings to extract from the field.
-	SplitAfter    []string // SplitAfter specifies the suffix strings to split the field after.
-	ClearZeroLeft string   // ClearZeroLeft specifies the parser to clear all zeros to the left of string.
-	LastDigits    int      // LastDigits specifies the parser to extract only the N digits at the end of string.
+	Deliminator   []int          // Deliminator holds the delimiter indices for the field.
+	TimeParse     string         // TimeParse specifies the time parsing format for the field.
+	TimePart      []int          // TimePart holds the delimiter indices of a second column appended to the field before parsing a time.
+	Location      *time.Location // Location specifies the time zone the parsed time is read in.
+	Precision     int            // Precision specifies the decimal precision for the field.
+	PrefixFrom    []string       // PrefixFrom specifies the prefix strings to extract from the field.
+	SplitAfter    []string       // SplitAfter specifies the suffix strings to split the field after.
+	ClearZeroLeft string         // ClearZeroLeft specifies the parser to clear all zeros to the left of string.
+	LastDigits    int            // LastDigits specifies the parser to extract only the N digits at the end of string.
+	BoolTokens    []string       // BoolTokens holds the true and false tokens of a boolean field.
+	Nested        *ParseOpt      // Nested holds the parsing options of a nested or embedded struct field.
+	Occurrences   []ParseParams  // Occurrences holds the parsing options of each element of a repeating group.
 }
 
 // LineTo parses a line of text and returns the parsed struct corresponding to the kind value.
@@ -87,6 +95,10 @@ func LineTo(line string, parseObjectFunc ParseObjectFunction) (structParsed inte
 		return
 	}
 
+	if err = validator.Validate(parseObject); err != nil {
+		return
+	}
+
 	return reflect.ValueOf(parseObject).Elem().Interface(), nil
 }
 
@@ -114,7 +126,7 @@ func LineTo(line string, parseObjectFunc ParseObjectFunction) (structParsed inte
 //	}
 func parseLine(line string, parseOpt ParseOpt, valueOf reflect.Value, typeOf reflect.Type) (err error) {
 	for index, param := range parseOpt.Params {
-		if err = setValues(line, valueOf.Field(index), param); err != nil {
+		if err = setField(line, valueOf.Field(index), param); err != nil {
 			return wraperrors.NewErrWrap(err, fmt.Errorf("at struct %s and field %s", valueOf.Type(), typeOf.Field(index).Name))
 		}
 	}
@@ -122,7 +134,52 @@ func parseLine(line string, parseOpt ParseOpt, valueOf reflect.Value, typeOf ref
 	return
 }
 
+// setField assigns a field from its parse params, descending into nested structs and repeating groups.
+// Fields without columns are not part of the layout and are left untouched.
+func setField(line string, v reflect.Value, param ParseParams) error {
+	switch {
+	case param.Nested != nil:
+		return parseLine(line, *param.Nested, v, v.Type())
+	case len(param.Occurrences) > 0:
+		return setOccurrences(line, v, param.Occurrences)
+	case len(param.Deliminator) == 0:
+		return nil
+	}
+
+	return setValues(line, v, param)
+}
+
+// setOccurrences assigns every element of an array or slice mapped to a repeating group.
+// Slices do not receive the trailing occurrences whose columns are blank.
+func setOccurrences(line string, v reflect.Value, occurrences []ParseParams) error {
+	elements := v
+
+	if v.Kind() == reflect.Slice {
+		count := len(occurrences)
+
+		for count > 0 && strings.TrimSpace(line[occurrences[count-1].Deliminator[0]:occurrences[count-1].Deliminator[1]+1]) == "" {
+			count--
+		}
+
+		elements = reflect.MakeSlice(v.Type(), count, count)
+	}
+
+	for index := 0; index < elements.Len(); index++ {
+		if err := setField(line, elements.Index(index), occurrences[index]); err != nil {
+			return wraperrors.NewErrWrap(err, fmt.Errorf("at occurrence %d", index+1))
+		}
+	}
+
+	if v.Kind() == reflect.Slice {
+		v.Set(elements)
+	}
+
+	return nil
+}
+
 // setValues parses a line of text and assigns the parsed value to a reflect.Value based on the provided ParseParams.
+// Fields implementing documenttranslator.FieldUnmarshaler decode their raw columns themselves, and
+// fields implementing encoding.TextUnmarshaler receive the trimmed column text.
 //
 // Parameters:
 // - line: The line of text to parse.
@@ -142,15 +199,142 @@ func parseLine(line string, parseOpt ParseOpt, valueOf reflect.Value, typeOf ref
 func setValues(line string, v reflect.Value, param ParseParams) error {
 	value := line[int(param.Deliminator[0]) : param.Deliminator[1]+1]
 
+	if v.Kind() == reflect.Pointer {
+		if columnIsEmpty(line, v.Type().Elem(), param) {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+
+		elem := reflect.New(v.Type().Elem())
+
+		if err := setValues(line, elem.Elem(), param); err != nil {
+			return err
+		}
+
+		v.Set(elem)
+		return nil
+	}
+
+	if unmarshaler, ok := v.Addr().Interface().(documenttranslator.FieldUnmarshaler); ok {
+		return unmarshaler.UnmarshalFixed([]byte(value))
+	}
+
 	switch v.Interface().(type) {
-	case int, int32, int64:
-		valueInt, err := strconv.Atoi(strings.TrimSpace(value))
+	case time.Time:
+		var timeParsed time.Time
+		var err error
+
+		if len(param.TimePart) > 0 {
+			value += line[param.TimePart[0] : param.TimePart[1]+1]
+		}
+
+		if dateValueIsEmpty(value) {
+			timeParsed = time.Time{}
+		} else if param.Location != nil {
+			timeParsed, err = time.ParseInLocation(param.TimeParse, value, param.Location)
+
+			if err != nil {
+				return err
+			}
+		} else {
+			timeParsed, err = time.Parse(param.TimeParse, value)
+
+			if err != nil {
+				return err
+			}
+
+			timeParsed = timeParsed.UTC()
+		}
+
+		v.Set(reflect.ValueOf(timeParsed))
+	case decimal.Decimal:
+		value = insertDecimalPoint(value, param.Precision)
+
+		valueDecimal, err := decimal.NewFromString(value)
 
 		if err != nil {
 			return err
 		}
-		v.SetInt(int64(valueInt))
-	case string:
+
+		v.Set(reflect.ValueOf(valueDecimal))
+	default:
+		if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(strings.TrimSpace(value)))
+		}
+
+		return setKindValue(value, v, param)
+	}
+
+	return nil
+}
+
+// insertDecimalPoint places the decimal point of a numeric column holding its last precision
+// digits as decimals. Blank columns read as zero and short columns are padded with zeros, so
+// "5" with precision 2 reads as "0.05".
+func insertDecimalPoint(value string, precision int) string {
+	value = strings.TrimSpace(value)
+	sign := ""
+
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		sign, value = value[:1], value[1:]
+	}
+
+	if len(value) <= precision {
+		value = strings.Repeat("0", precision+1-len(value)) + value
+	}
+
+	return sign + value[:len(value)-precision] + "." + value[len(value)-precision:]
+}
+
+// setKindValue assigns a column value to a field by its reflect.Kind, so named types
+// such as `type RegisterType string` are parsed like their underlying type.
+//
+// Parameters:
+// - value: The raw column value.
+// - v: A reflect.Value representing the target value to assign the parsed result.
+// - param: The ParseParams containing parsing options for the value.
+//
+// Returns:
+// - An error if the value cannot be converted to the field kind.
+// - nil if the value was assigned or the kind is not supported.
+func setKindValue(value string, v reflect.Value, param ParseParams) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		valueInt, err := strconv.ParseInt(strings.TrimSpace(value), 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+		v.SetInt(valueInt)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		valueUint, err := strconv.ParseUint(strings.TrimSpace(value), 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+		v.SetUint(valueUint)
+	case reflect.Float32, reflect.Float64:
+		value = insertDecimalPoint(value, param.Precision)
+
+		valueFloat, err := strconv.ParseFloat(value, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+		v.SetFloat(valueFloat)
+	case reflect.Bool:
+		trueToken, falseToken := boolTokens(param)
+		value = strings.TrimSpace(value)
+
+		switch value {
+		case trueToken:
+			v.SetBool(true)
+		case falseToken, "":
+			v.SetBool(false)
+		default:
+			return wraperrors.NewErrWrap(documenttranslator.ErrInvalidBoolToken, fmt.Errorf("value %q expected %q or %q", value, trueToken, falseToken))
+		}
+	case reflect.String:
 		if len(param.PrefixFrom) > 0 {
 			for _, prefixValue := range param.PrefixFrom {
 				index := strings.Index(value, prefixValue)
@@ -189,41 +373,39 @@ func setValues(line string, v reflect.Value, param ParseParams) error {
 		value := strings.TrimSpace(value)
 
 		v.SetString(value)
-	case time.Time:
-		var timeParsed time.Time
-		var err error
-
-		if dateValueIsEmpty(value) {
-			timeParsed = time.Time{}
-		} else {
-			timeParsed, err = time.Parse(param.TimeParse, value)
+	}
 
-			if err != nil {
-				return err
-			}
+	return nil
+}
 
-		}
+// boolTokens returns the true and false tokens of a boolean field, "1" and "0" when not tagged.
+func boolTokens(param ParseParams) (string, string) {
+	if len(param.BoolTokens) == 2 {
+		return param.BoolTokens[0], param.BoolTokens[1]
+	}
 
-		v.Set(reflect.ValueOf(timeParsed.UTC()))
-	case decimal.Decimal:
-		value = strings.TrimSpace(value)
-		value = value[:len(value)-param.Precision] + "." + value[(len(value))-param.Precision:]
+	return "1", "0"
+}
 
-		valueDecimal, err := decimal.NewFromString(value)
+// columnIsEmpty reports whether the columns of an optional field hold no information:
+// blank for strings, blank or all zeros for any other type.
+func columnIsEmpty(line string, elemType reflect.Type, param ParseParams) bool {
+	value := line[param.Deliminator[0] : param.Deliminator[1]+1]
 
-		if err != nil {
-			return err
-		}
+	if elemType.Kind() == reflect.String {
+		return strings.TrimSpace(value) == ""
+	}
 
-		v.Set(reflect.ValueOf(valueDecimal))
+	if len(param.TimePart) > 0 {
+		value += line[param.TimePart[0] : param.TimePart[1]+1]
 	}
 
-	return nil
+	return strings.Trim(value, "0., ") == ""
 }
 
 func dateValueIsEmpty(value string) bool {
-	// value can be "00000000" or "0000.00.00"
-	return strings.Trim(value, "0.") == ""
+	// value can be "00000000", "0000.00.00" or blank
+	return strings.Trim(value, "0. ") == ""
 }
 
 // convertStringToIntSlice converts a slice of string values to a slice of integers.
@@ -272,10 +454,20 @@ func convertStringToIntSlice(values ...string) (ret []int, err error) {
 //	    // Handle the error
 //	}
 func extractTags(structTagged reflect.Type) (parseOpt ParseOpt, err error) {
+	return extractTagsAt(structTagged, 0)
+}
+
+// extractTagsAt extracts the tags of a struct whose columns start at offset. Struct fields
+// without a part tag are nested layouts: their columns are absolute, counted from the start
+// of the line however deep they are nested, or, with an offset tag, relative to the columns
+// of the struct holding them.
+func extractTagsAt(structTagged reflect.Type, offset int) (parseOpt ParseOpt, err error) {
 	parseOpt.Params = make([]ParseParams, structTagged.NumField())
 
 	for i := 0; i < structTagged.NumField(); i++ {
 		f := structTagged.Field(i)
+		fieldOffset := 0
+		occurs := 0
 
 		translatorTags := strings.Split(f.Tag.Get("translator"), ";")
 
@@ -287,7 +479,7 @@ func extractTags(structTagged reflect.Type) (parseOpt ParseOpt, err error) {
 				continue
 			}
 
-			resultGroup := strings.Split(key, ":")
+			resultGroup := strings.SplitN(key, ":", 2)
 
 			switch resultGroup[0] {
 			case "part":
@@ -297,7 +489,18 @@ func extractTags(structTagged reflect.Type) (parseOpt ParseOpt, err error) {
 					return parseOpt, err
 				}
 
-				parseOpt.Params[i].Deliminator = convertToInt
+				parseOpt.Params[i].Deliminator = addOffset(convertToInt, offset)
+			case "offset":
+				nestedOffset, err := strconv.Atoi(resultGroup[1])
+				if err != nil {
+					return parseOpt, err
+				}
+				fieldOffset = offset + nestedOffset
+			case "occurs":
+				occurs, err = strconv.Atoi(resultGroup[1])
+				if err != nil {
+					return parseOpt, err
+				}
 			case "clearZeroLeft":
 				parseOpt.Params[i].ClearZeroLeft = resultGroup[0]
 			case "lastDigits":
@@ -308,6 +511,22 @@ func extractTags(structTagged reflect.Type) (parseOpt ParseOpt, err error) {
 				parseOpt.Params[i].LastDigits = nDigits
 			case "timeParse":
 				parseOpt.Params[i].TimeParse = resultGroup[1]
+			case "timePart":
+				convertToInt, err := convertStringToIntSlice(strings.Split(resultGroup[1], "..")...)
+
+				if err != nil {
+					return parseOpt, err
+				}
+
+				parseOpt.Params[i].TimePart = addOffset(convertToInt, offset)
+			case "tz":
+				location, err := utils.LoadLocation(resultGroup[1])
+
+				if err != nil {
+					return parseOpt, err
+				}
+
+				parseOpt.Params[i].Location = location
 			case "kind":
 				parseOpt.Kind.FieldIndex = new(int)
 				*parseOpt.Kind.FieldIndex = i
@@ -322,17 +541,97 @@ func extractTags(structTagged reflect.Type) (parseOpt ParseOpt, err error) {
 					return parseOpt, err
 				}
 				parseOpt.Params[i].Precision = precision
+			case "bool":
+				tokens := strings.Split(resultGroup[1], ",")
+
+				if len(tokens) != 2 {
+					return parseOpt, fmt.Errorf("bool option %q must have a true and a false token", resultGroup[1])
+				}
+
+				parseOpt.Params[i].BoolTokens = tokens
 			case "prefixFrom":
 				parseOpt.Params[i].PrefixFrom = strings.Split(resultGroup[1], ",")
 			case "splitAfter":
 				parseOpt.Params[i].SplitAfter = strings.Split(resultGroup[1], ",")
 			}
 		}
+
+		if occurs > 0 {
+			if parseOpt.Params[i].Occurrences, err = extractOccurrences(f.Type, parseOpt.Params[i], occurs); err != nil {
+				return parseOpt, wraperrors.NewErrWrap(err, fmt.Errorf("at field %s", f.Name))
+			}
+
+			continue
+		}
+
+		if len(parseOpt.Params[i].Deliminator) == 0 && utils.IsNestedStruct(f.Type) {
+			nested, err := extractTagsAt(f.Type, fieldOffset)
+
+			if err != nil {
+				return parseOpt, err
+			}
+
+			parseOpt.Params[i].Nested = &nested
+		}
 	}
 
 	return
 }
 
+// extractOccurrences splits the columns of a repeating group field into occurs elements of
+// equal width. Struct elements are laid out relative to the start of each element, other
+// elements reuse the options of the field.
+func extractOccurrences(fieldType reflect.Type, param ParseParams, occurs int) ([]ParseParams, error) {
+	if fieldType.Kind() != reflect.Array && fieldType.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("occurs requires an array or slice, got %s", fieldType)
+	}
+
+	if fieldType.Kind() == reflect.Array && fieldType.Len() != occurs {
+		return nil, fmt.Errorf("occurs:%d does not match array length %d", occurs, fieldType.Len())
+	}
+
+	if len(param.Deliminator) != 2 {
+		return nil, fmt.Errorf("occurs requires a part")
+	}
+
+	width := param.Deliminator[1] - param.Deliminator[0] + 1
+
+	if width%occurs != 0 {
+		return nil, fmt.Errorf("part %d..%d cannot be split in %d occurrences", param.Deliminator[0], param.Deliminator[1], occurs)
+	}
+
+	width /= occurs
+	occurrences := make([]ParseParams, occurs)
+
+	for index := range occurrences {
+		start := param.Deliminator[0] + index*width
+
+		if utils.IsNestedStruct(fieldType.Elem()) {
+			nested, err := extractTagsAt(fieldType.Elem(), start)
+
+			if err != nil {
+				return nil, err
+			}
+
+			occurrences[index] = ParseParams{Deliminator: []int{start, start + width - 1}, Nested: &nested}
+			continue
+		}
+
+		occurrences[index] = param
+		occurrences[index].Deliminator = []int{start, start + width - 1}
+	}
+
+	return occurrences, nil
+}
+
+func addOffset(deliminator []int, offset int) []int {
+	for index := range deliminator {
+		deliminator[index] += offset
+	}
+
+	return deliminator
+}
+
 // validateKindAndSegment validates the consistency of the kind and segment fields within a struct.
 // It performs different validations based on the kind of the field.
 //
@@ -425,9 +724,23 @@ func checkDeliminatorSize(line string, parseOpt ParseOpt, valueOf reflect.Value)
 	var highestDeliminator int
 
 	for _, opt := range parseOpt.Params {
+		if opt.Nested != nil {
+			if err := checkDeliminatorSize(line, *opt.Nested, valueOf); err != nil {
+				return err
+			}
+		}
+
+		if len(opt.Deliminator) == 0 {
+			continue
+		}
+
 		if opt.Deliminator[1] > highestDeliminator {
 			highestDeliminator = opt.Deliminator[1]
 		}
+
+		if len(opt.TimePart) > 0 && opt.TimePart[1] > highestDeliminator {
+			highestDeliminator = opt.TimePart[1]
+		}
 	}
 
 	if highestDeliminator > len(line) {
@@ -588,3 +588,347 @@ func TestUnicodeSpecialCharacter(t *testing.T) {
 		})
 	}
 }
+
+func TestLineToTimePartAndTimeZone(t *testing.T) {
+	type TestStruct struct {
+		Date     time.Time `translator:"part:0..7;timeParse:02012006"`
+		Hour     string    `translator:"part:8..13"`
+		DateTime time.Time `translator:"part:0..7;timePart:8..13;timeParse:02012006150405;tz:America/Sao_Paulo"`
+		Empty    time.Time `translator:"part:14..21;timePart:22..27;timeParse:02012006150405;tz:America/Sao_Paulo"`
+	}
+
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+
+	parsed, err := LineTo("16092024071329000000000000000", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		Date:     time.Date(2024, time.September, 16, 0, 0, 0, 0, time.UTC),
+		Hour:     "071329",
+		DateTime: time.Date(2024, time.September, 16, 7, 13, 29, 0, location),
+	}, parsed)
+	assert.Equal(t, "2024-09-16T10:13:29Z", parsed.(TestStruct).DateTime.UTC().Format(time.RFC3339))
+}
+
+func TestLineToTagValuesWithColons(t *testing.T) {
+	type TestStruct struct {
+		Time time.Time `translator:"part:0..7;timeParse:15:04:05"`
+	}
+
+	parsed, err := LineTo("10:44:22", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10:44:22", parsed.(TestStruct).Time.Format("15:04:05"))
+}
+
+func Test_extractTagsInvalidTimeZone(t *testing.T) {
+	type TestStruct struct {
+		Field1 time.Time `translator:"part:0..7;timeParse:02012006;tz:America/Nowhere"`
+	}
+
+	_, err := extractTags(reflect.TypeOf(TestStruct{}))
+
+	assert.Error(t, err)
+}
+
+func TestLineToOptionalFields(t *testing.T) {
+	type TestStruct struct {
+		Date   *time.Time       `translator:"part:0..7;timeParse:02012006"`
+		Amount *decimal.Decimal `translator:"part:8..13;precision:2"`
+		Number *int             `translator:"part:14..16"`
+		Text   *string          `translator:"part:17..20"`
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want TestStruct
+	}{
+		{
+			name: "should parse blank and all zero columns as nil",
+			line: "00000000      000    ",
+			want: TestStruct{},
+		},
+		{
+			name: "should parse informed columns",
+			line: "160920240012340420000",
+			want: TestStruct{
+				Date:   utils.PtrAny(time.Date(2024, time.September, 16, 0, 0, 0, 0, time.UTC)),
+				Amount: utils.PtrAny(decimal.RequireFromString("12.34")),
+				Number: utils.PtrAny(42),
+				Text:   utils.PtrAny("0000"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := LineTo(tt.line, func(line string) interface{} {
+				return new(TestStruct)
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, parsed)
+		})
+	}
+}
+
+func TestLineToKinds(t *testing.T) {
+	type RegisterType string
+
+	type TestStruct struct {
+		Register RegisterType `translator:"part:0..0"`
+		Small    int16        `translator:"part:1..3"`
+		Unsigned uint         `translator:"part:4..6"`
+		Rate     float64      `translator:"part:7..10;precision:2"`
+		Flag     bool         `translator:"part:11..11;bool:S,N"`
+		Default  bool         `translator:"part:12..12"`
+		Blank    bool         `translator:"part:13..13;bool:S,N"`
+	}
+
+	parsed, err := LineTo("21230451234S0 ", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		Register: "2",
+		Small:    123,
+		Unsigned: 45,
+		Rate:     12.34,
+		Flag:     true,
+		Default:  false,
+		Blank:    false,
+	}, parsed)
+}
+
+func TestLineToBlankNumbers(t *testing.T) {
+	type TestStruct struct {
+		Rate    float64         `translator:"part:0..3;precision:2"`
+		Short   float64         `translator:"part:4..7;precision:3"`
+		Value   decimal.Decimal `translator:"part:8..11;precision:2"`
+		Integer decimal.Decimal `translator:"part:12..13;precision:0"`
+	}
+
+	parsed, err := LineTo("       5        ", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, parsed.(TestStruct).Rate)
+	assert.Equal(t, 0.005, parsed.(TestStruct).Short)
+	assert.Equal(t, "0.00", parsed.(TestStruct).Value.StringFixed(2))
+	assert.True(t, parsed.(TestStruct).Integer.IsZero())
+}
+
+func TestLineToKindsErrors(t *testing.T) {
+	type TestStructInvalidBool struct {
+		Flag bool `translator:"part:0..0;bool:S,N"`
+	}
+
+	type TestStructOverflow struct {
+		Small int8 `translator:"part:0..2"`
+	}
+
+	type TestStructNegativeUnsigned struct {
+		Unsigned uint `translator:"part:0..1"`
+	}
+
+	_, err := LineTo("X", func(line string) interface{} { return new(TestStructInvalidBool) })
+	assert.ErrorIs(t, err, documenttranslator.ErrInvalidBoolToken)
+
+	_, err = LineTo("999", func(line string) interface{} { return new(TestStructOverflow) })
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = LineTo("-1", func(line string) interface{} { return new(TestStructNegativeUnsigned) })
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+type fixedDocument struct {
+	Root    string
+	Control string
+}
+
+func (d *fixedDocument) UnmarshalFixed(data []byte) error {
+	if len(data) != 6 {
+		return strconv.ErrSyntax
+	}
+
+	d.Root, d.Control = string(data[:4]), string(data[4:])
+
+	return nil
+}
+
+type textZipCode string
+
+func (z *textZipCode) UnmarshalText(text []byte) error {
+	*z = textZipCode(string(text[:5]) + "-" + string(text[5:]))
+
+	return nil
+}
+
+func TestLineToFieldUnmarshalers(t *testing.T) {
+	type TestStruct struct {
+		Document fixedDocument  `translator:"part:0..5"`
+		ZipCode  textZipCode    `translator:"part:6..14"`
+		Optional *fixedDocument `translator:"part:15..20"`
+	}
+
+	parsed, err := LineTo("123401 01310100      ", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		Document: fixedDocument{Root: "1234", Control: "01"},
+		ZipCode:  "01310-100",
+	}, parsed)
+}
+
+type testControl struct {
+	BankCode    string `translator:"part:0..2"`
+	BatchNumber int    `translator:"part:3..6"`
+}
+
+type testBankAccount struct {
+	Agency    string `translator:"part:0..4"`
+	AgencyCd  string `translator:"part:5..5"`
+	Account   string `translator:"part:6..9"`
+	AccountCd string `translator:"part:10..10"`
+}
+
+func TestLineToNestedStructs(t *testing.T) {
+	type TestStruct struct {
+		testControl
+		RegistryKind int             `translator:"part:7..7;kind:1"`
+		Account      testBankAccount `translator:"offset:8"`
+		Other        testBankAccount `translator:"offset:19"`
+		Ignored      string
+	}
+
+	parsed, err := LineTo("353000111893701234512345600002", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		testControl:  testControl{BankCode: "353", BatchNumber: 1},
+		RegistryKind: 1,
+		Account:      testBankAccount{Agency: "18937", AgencyCd: "0", Account: "1234", AccountCd: "5"},
+		Other:        testBankAccount{Agency: "12345", AgencyCd: "6", Account: "0000", AccountCd: "2"},
+	}, parsed)
+}
+
+type testAccountHolder struct {
+	testControl
+	Name    string          `translator:"part:0..1"`
+	Account testBankAccount `translator:"offset:2"`
+}
+
+func TestLineToDeeplyNestedStructs(t *testing.T) {
+	type TestStruct struct {
+		Holder testAccountHolder `translator:"offset:10"`
+	}
+
+	parsed, err := LineTo("3530001000XY18937012345", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		Holder: testAccountHolder{
+			testControl: testControl{BankCode: "353", BatchNumber: 1},
+			Name:        "XY",
+			Account:     testBankAccount{Agency: "18937", AgencyCd: "0", Account: "1234", AccountCd: "5"},
+		},
+	}, parsed)
+}
+
+func TestLineToNestedStructsShorterLine(t *testing.T) {
+	type TestStruct struct {
+		Kind    string          `translator:"part:0..0"`
+		Account testBankAccount `translator:"offset:10"`
+	}
+
+	_, err := LineTo("1234567890", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.ErrorIs(t, err, documenttranslator.ErrParseShorterThenDeliminator)
+}
+
+type testFiscalDocument struct {
+	Number string           `translator:"part:0..2"`
+	Value  *decimal.Decimal `translator:"part:3..6;precision:2"`
+}
+
+func TestLineToOccurs(t *testing.T) {
+	type TestStruct struct {
+		Kind      string                `translator:"part:0..0"`
+		Documents []testFiscalDocument  `translator:"part:1..21;occurs:3"`
+		Fixed     [3]testFiscalDocument `translator:"part:1..21;occurs:3"`
+		Buckets   [2]decimal.Decimal    `translator:"part:22..27;occurs:2;precision:1"`
+	}
+
+	parsed, err := LineTo("Y00101230020456       000123", func(line string) interface{} {
+		return new(TestStruct)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct{
+		Kind: "Y",
+		Documents: []testFiscalDocument{
+			{Number: "001", Value: utils.PtrAny(decimal.RequireFromString("1.23"))},
+			{Number: "002", Value: utils.PtrAny(decimal.RequireFromString("4.56"))},
+		},
+		Fixed: [3]testFiscalDocument{
+			{Number: "001", Value: utils.PtrAny(decimal.RequireFromString("1.23"))},
+			{Number: "002", Value: utils.PtrAny(decimal.RequireFromString("4.56"))},
+		},
+		Buckets: [2]decimal.Decimal{decimal.RequireFromString("0.0"), decimal.RequireFromString("12.3")},
+	}, parsed)
+}
+
+func Test_extractTagsInvalidOccurs(t *testing.T) {
+	type TestStructUneven struct {
+		Field1 [2]string `translator:"part:0..4;occurs:2"`
+	}
+
+	type TestStructLength struct {
+		Field1 [3]string `translator:"part:0..5;occurs:2"`
+	}
+
+	type TestStructNotRepeating struct {
+		Field1 string `translator:"part:0..5;occurs:2"`
+	}
+
+	for _, structTagged := range []reflect.Type{
+		reflect.TypeOf(TestStructUneven{}),
+		reflect.TypeOf(TestStructLength{}),
+		reflect.TypeOf(TestStructNotRepeating{}),
+	} {
+		_, err := extractTags(structTagged)
+
+		assert.Error(t, err, structTagged.String())
+	}
+}
+
+func TestLineToValidation(t *testing.T) {
+	type TestStructValidated struct {
+		DocumentKind int    `translator:"part:0..0" validate:"oneof:1,2"`
+		Name         string `translator:"part:1..4" validate:"required"`
+	}
+
+	parsed, err := LineTo("2LIBE", func(line string) interface{} { return new(TestStructValidated) })
+	assert.Nil(t, err)
+	assert.Equal(t, TestStructValidated{DocumentKind: 2, Name: "LIBE"}, parsed)
+
+	_, err = LineTo("7    ", func(line string) interface{} { return new(TestStructValidated) })
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.EqualError(t, err, "field TestStructValidated.DocumentKind with value 7 does not satisfy oneof:1,2; field TestStructValidated.Name with value  does not satisfy required")
+}
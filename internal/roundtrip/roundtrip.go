@@ -0,0 +1,415 @@
+// Package roundtrip checks that record types write the lines they parse, byte for byte.
+package roundtrip
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/checkdigit"
+	"github.com/libercapital/document-translator-go/internal/parser"
+	"github.com/libercapital/document-translator-go/internal/utils"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	textAlphabet  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789 -/."
+	digitAlphabet = "0123456789"
+	maxDigits     = 18
+	maxAttempts   = 1000
+)
+
+// Check writes records filled with random values honouring their translator and validate
+// tags, parses every line back and asserts that the parsed record writes the very same line.
+// Validated fields get values satisfying their rules, e.g. documents with valid check digits;
+// records still rejected, by rules spanning several fields, are skipped, and adjust may replace
+// random values so those records are also covered.
+//
+// Parameters:
+//   - t: The test.
+//   - record: A value of the record type, e.g. brf240.BillingSegmentA{}.
+//   - iterations: The number of random records to check.
+//   - adjust: An optional function receiving a pointer to each random record before it is written.
+func Check(t *testing.T, record documenttranslator.Record, iterations int, adjust func(record interface{})) {
+	t.Helper()
+
+	recordType := reflect.TypeOf(record)
+	random := rand.New(rand.NewSource(1))
+	checked := 0
+
+	for i := 0; i < iterations; i++ {
+		value := reflect.New(recordType)
+
+		if err := fill(value.Elem(), random); err != nil {
+			t.Fatalf("%s: %v", recordType.Name(), err)
+		}
+
+		if adjust != nil {
+			adjust(value.Interface())
+		}
+
+		line, err := value.Elem().Interface().(documenttranslator.Record).String()
+
+		if errors.Is(err, documenttranslator.ErrValidation) {
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%s: writing %+v: %v", recordType.Name(), value.Elem().Interface(), err)
+		}
+
+		parsed, err := parser.LineTo(line, func(string) interface{} { return reflect.New(recordType).Interface() })
+
+		if err != nil {
+			t.Fatalf("%s: parsing %q: %v", recordType.Name(), line, err)
+		}
+
+		rewritten, err := parsed.(documenttranslator.Record).String()
+
+		if err != nil {
+			t.Fatalf("%s: writing parsed %q: %v", recordType.Name(), line, err)
+		}
+
+		if rewritten != line {
+			t.Fatalf("%s: line changed after round trip\nwritten:   %q\nrewritten: %q", recordType.Name(), line, rewritten)
+		}
+
+		checked++
+	}
+
+	if checked == 0 {
+		t.Fatalf("%s: every random record was rejected by validation", recordType.Name())
+	}
+}
+
+// CheckLines asserts that each line parses with parse and writes back unchanged.
+func CheckLines(t *testing.T, parse func(line string) (interface{}, error), lines ...string) {
+	t.Helper()
+
+	for _, line := range lines {
+		parsed, err := parse(line)
+
+		if err != nil {
+			t.Fatalf("parsing %q: %v", line, err)
+		}
+
+		record, ok := parsed.(documenttranslator.Record)
+
+		if !ok {
+			t.Fatalf("%T does not write lines", parsed)
+		}
+
+		written, err := record.String()
+
+		if err != nil {
+			t.Fatalf("writing %T: %v", parsed, err)
+		}
+
+		if written != line {
+			t.Errorf("%T: line changed after round trip\nparsed:  %q\nwritten: %q", parsed, line, written)
+		}
+	}
+}
+
+// fill assigns random values to the tagged fields of a struct, in the canonical form the
+// writer produces: text without surrounding blanks and numbers fitting their columns. Columns
+// split with prefixFrom and splitAfter sometimes get no marker, both fields then holding the
+// whole column as the parser reads it.
+func fill(structValue reflect.Value, random *rand.Rand) error {
+	structType := structValue.Type()
+	prefixes := map[string]string{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("translator")
+
+		if tag == "" && !(field.Anonymous && field.IsExported() && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		options := map[string]string{}
+
+		for _, option := range strings.Split(tag, ";") {
+			keyValuePair := strings.SplitN(option, ":", 2)
+			options[keyValuePair[0]] = ""
+
+			if len(keyValuePair) > 1 {
+				options[keyValuePair[0]] = keyValuePair[1]
+			}
+		}
+
+		if _, ok := options["part"]; !ok && field.Type.Kind() == reflect.Struct {
+			if err := fill(structValue.Field(i), random); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		bounds := strings.Split(options["part"], "..")
+		start, _ := strconv.Atoi(bounds[0])
+		end, _ := strconv.Atoi(bounds[len(bounds)-1])
+		width := end - start + 1
+
+		if occurs, ok := options["occurs"]; ok {
+			count, _ := strconv.Atoi(occurs)
+
+			if err := fillOccurrences(structValue.Field(i), options, field.Tag.Get("validate"), count, width/count, random); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+
+			continue
+		}
+
+		if fixed, ok := options["kind"]; ok {
+			if err := setText(structValue.Field(i), fixed); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if fixed, ok := options["segment"]; ok {
+			if err := setText(structValue.Field(i), fixed); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value, err := randomValidValue(field.Type, options, field.Tag.Get("validate"), width, random)
+
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if markers, ok := options["prefixFrom"]; ok {
+			list := strings.Split(markers, ",")
+			prefix := randomText(digitAlphabet, 1+random.Intn(width/2), random)
+
+			if random.Intn(4) > 0 {
+				prefix += list[random.Intn(len(list))]
+			}
+
+			prefixes[options["part"]] = prefix
+			value = reflect.ValueOf(prefix)
+		}
+
+		if markers, ok := options["splitAfter"]; ok {
+			prefix := prefixes[options["part"]]
+			value = reflect.ValueOf(prefix)
+
+			if endsWithAny(prefix, strings.Split(markers, ",")) {
+				remaining := width - len(prefix)
+				value = reflect.ValueOf(randomText(digitAlphabet, 1+random.Intn(remaining), random))
+			}
+		}
+
+		structValue.Field(i).Set(value.Convert(field.Type))
+	}
+
+	return nil
+}
+
+// endsWithAny reports whether text ends with one of the markers.
+func endsWithAny(text string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.HasSuffix(text, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fillOccurrences fills every element of a repeating group, sized to its occurs count.
+func fillOccurrences(group reflect.Value, options map[string]string, rules string, count int, width int, random *rand.Rand) error {
+	if group.Kind() == reflect.Slice {
+		group.Set(reflect.MakeSlice(group.Type(), count, count))
+	}
+
+	for i := 0; i < group.Len(); i++ {
+		element := group.Index(i)
+
+		if utils.IsNestedStruct(element.Type()) {
+			if err := fill(element, random); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value, err := randomValidValue(element.Type(), options, rules, width, random)
+
+		if err != nil {
+			return err
+		}
+
+		element.Set(value.Convert(element.Type()))
+	}
+
+	return nil
+}
+
+// randomValidValue returns a random value satisfying the validate rules of a field. Documents
+// and oneof values are generated; other rules are met by drawing random values until they pass.
+func randomValidValue(fieldType reflect.Type, options map[string]string, rules string, width int, random *rand.Rand) (reflect.Value, error) {
+	var checks []func(reflect.Value) bool
+
+	for _, rule := range strings.Split(rules, ";") {
+		name, argument, _ := strings.Cut(rule, ":")
+
+		switch name {
+		case "oneof":
+			choices := strings.Split(argument, ",")
+			value := reflect.New(fieldType).Elem()
+
+			return value, setText(value, choices[random.Intn(len(choices))])
+		case "cpf", "cnpj", "cpfcnpj":
+			document, err := randomDocument(name, width, random)
+
+			return reflect.ValueOf(document), err
+		case "required":
+			checks = append(checks, func(value reflect.Value) bool { return !value.IsZero() })
+		case "regex":
+			pattern, err := regexp.Compile(argument)
+
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			checks = append(checks, func(value reflect.Value) bool {
+				return value.Kind() == reflect.String && pattern.MatchString(value.String())
+			})
+		}
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		value, err := randomValue(fieldType, options, width, random)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		valid := true
+
+		for _, check := range checks {
+			valid = valid && check(value)
+		}
+
+		if valid {
+			return value, nil
+		}
+	}
+
+	return reflect.Value{}, errors.New("no random value satisfies the rules " + rules)
+}
+
+// randomDocument returns a CPF or CNPJ with valid check digits fitting the width of its column.
+func randomDocument(rule string, width int, random *rand.Rand) (string, error) {
+	if rule == "cpfcnpj" {
+		rule = "cnpj"
+
+		if width < 14 || random.Intn(2) == 0 {
+			rule = "cpf"
+		}
+	}
+
+	if rule == "cpf" {
+		base := randomText(digitAlphabet, 9, random)
+		digits, err := checkdigit.CPFCheckDigits(base)
+
+		return base + digits, err
+	}
+
+	base := randomText(digitAlphabet, 12, random)
+	digits, err := checkdigit.CNPJCheckDigits(base)
+
+	return base + digits, err
+}
+
+func randomValue(fieldType reflect.Type, options map[string]string, width int, random *rand.Rand) (reflect.Value, error) {
+	digits := width
+
+	if digits > maxDigits {
+		digits = maxDigits
+	}
+
+	switch fieldType {
+	case reflect.TypeOf(time.Time{}):
+		moment := time.Date(1970+random.Intn(130), time.Month(1+random.Intn(12)), 1+random.Intn(28), random.Intn(24), random.Intn(60), random.Intn(60), 0, time.UTC)
+		return reflect.ValueOf(moment), nil
+	case reflect.TypeOf(decimal.Decimal{}):
+		precision := 2
+
+		if value, ok := options["precision"]; ok {
+			precision, _ = strconv.Atoi(value)
+		}
+
+		return reflect.ValueOf(decimal.New(random.Int63n(pow10(digits)), int32(-precision))), nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int64:
+		return reflect.ValueOf(random.Int63n(pow10(digits))), nil
+	case reflect.String:
+		if lastDigits, ok := options["lastDigits"]; ok {
+			count, _ := strconv.Atoi(lastDigits)
+			return reflect.ValueOf(randomText(digitAlphabet, count, random)), nil
+		}
+
+		if _, ok := options["clearZeroLeft"]; ok {
+			return reflect.ValueOf(strings.TrimLeft(randomText(digitAlphabet, random.Intn(width+1), random), "0")), nil
+		}
+
+		return reflect.ValueOf(strings.TrimSpace(randomText(textAlphabet, random.Intn(width+1), random))), nil
+	}
+
+	return reflect.Value{}, errors.New("unsupported field type " + fieldType.String())
+}
+
+func setText(field reflect.Value, text string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int64:
+		number, err := strconv.ParseInt(text, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(number)
+	default:
+		return errors.New("unsupported fixed field type " + field.Type().String())
+	}
+
+	return nil
+}
+
+func randomText(alphabet string, length int, random *rand.Rand) string {
+	var text strings.Builder
+
+	for i := 0; i < length; i++ {
+		text.WriteByte(alphabet[random.Intn(len(alphabet))])
+	}
+
+	return text.String()
+}
+
+func pow10(exponent int) int64 {
+	result := int64(1)
+
+	for i := 0; i < exponent; i++ {
+		result *= 10
+	}
+
+	return result
+}
@@ -1,6 +1,24 @@
 package utils
 
-import "strconv"
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata" // tz tags must resolve on hosts without a zoneinfo database
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+)
+
+var (
+	fieldUnmarshalerType = reflect.TypeOf((*documenttranslator.FieldUnmarshaler)(nil)).Elem()
+	fieldMarshalerType   = reflect.TypeOf((*documenttranslator.FieldMarshaler)(nil)).Elem()
+	textUnmarshalerType  = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType    = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	locations sync.Map // locations caches the *time.Location of each tz tag by name.
+)
 
 func ConvertStringToIntSlice(values ...string) (ret []int, err error) {
 	for _, value := range values {
@@ -27,3 +45,39 @@ func EmptyArray(length int) []byte {
 func PtrAny[T any](value T) *T {
 	return &value
 }
+
+// IsNestedStruct reports whether a field type is a struct laid out field by field,
+// rather than a value type such as time.Time, decimal.Decimal or a custom codec.
+func IsNestedStruct(fieldType reflect.Type) bool {
+	if fieldType.Kind() != reflect.Struct {
+		return false
+	}
+
+	pointerType := reflect.PointerTo(fieldType)
+
+	for _, codecType := range []reflect.Type{fieldUnmarshalerType, fieldMarshalerType, textUnmarshalerType, textMarshalerType} {
+		if pointerType.Implements(codecType) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LoadLocation returns the time zone of a tz tag, loading it once per name instead of on
+// every line parsed or written.
+func LoadLocation(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+
+	location, err := time.LoadLocation(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, location)
+
+	return location, nil
+}
@@ -79,3 +79,16 @@ func Test_PtrAny(t *testing.T) {
 		})
 	}
 }
+
+func Test_LoadLocation(t *testing.T) {
+	location, err := LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+	assert.Equal(t, "America/Sao_Paulo", location.String())
+
+	cached, err := LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+	assert.Same(t, location, cached)
+
+	_, err = LoadLocation("America/Atlantis")
+	assert.Error(t, err)
+}
@@ -0,0 +1,356 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/checkdigit"
+	"github.com/libercapital/document-translator-go/internal/utils"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+	"github.com/shopspring/decimal"
+)
+
+type rule struct {
+	Name  string // Name is the rule name, e.g. "oneof".
+	Value string // Value is the rule argument, e.g. "1,2".
+
+	options []string        // options are the values accepted by oneof.
+	pattern *regexp.Regexp  // pattern is the compiled expression of regex.
+	limit   decimal.Decimal // limit is the bound of min and max.
+}
+
+func (r rule) String() string {
+	if r.Value == "" {
+		return r.Name
+	}
+
+	return r.Name + ":" + r.Value
+}
+
+// fieldRules are the rules of a struct field, or the error of its invalid validate tag.
+type fieldRules struct {
+	rules []rule
+	err   error
+}
+
+// structRules caches the []fieldRules of each struct type, indexed as its fields.
+var structRules sync.Map
+
+// Validate evaluates the `validate` tags of a struct, descending into nested structs,
+// arrays and slices, and returns documenttranslator.ValidationErrors listing every
+// rejected field.
+//
+// Supported rules, separated by ";":
+// - required: the field must not be blank, zero or nil.
+// - oneof:a,b: the field formatted as text must be one of the listed values.
+// - regex:pattern: the trimmed text of the field must match the pattern.
+// - min:n / max:n: numbers must be within the range, strings must have the length.
+// - cpf, cnpj, cpfcnpj: the field must hold a document with valid check digits.
+//
+// Every rule but required accepts blank, zero and nil values.
+//
+// Example:
+//
+//	err := Validate(record)
+//	if errors.Is(err, documenttranslator.ErrValidation) {
+//	    // Handle the rejected fields
+//	}
+func Validate(value interface{}) error {
+	valueOf := reflect.ValueOf(value)
+
+	for valueOf.Kind() == reflect.Pointer {
+		if valueOf.IsNil() {
+			return nil
+		}
+
+		valueOf = valueOf.Elem()
+	}
+
+	if valueOf.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var validationErrors documenttranslator.ValidationErrors
+
+	if err := validateStruct(valueOf, "", valueOf.Type().Name(), &validationErrors); err != nil {
+		return err
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+func validateStruct(valueOf reflect.Value, prefix string, structName string, validationErrors *documenttranslator.ValidationErrors) error {
+	typeOf := valueOf.Type()
+	fields := rulesOf(typeOf)
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		f := typeOf.Field(i)
+		field := valueOf.Field(i)
+
+		if !field.CanInterface() {
+			continue
+		}
+
+		fieldName := prefix + f.Name
+
+		if fields[i].err != nil {
+			return wraperrors.NewErrWrap(fields[i].err, fmt.Errorf("at struct %s and field %s", structName, fieldName))
+		}
+
+		for _, rule := range fields[i].rules {
+			ok, err := check(rule, field)
+
+			if err != nil {
+				return wraperrors.NewErrWrap(err, fmt.Errorf("at struct %s and field %s", structName, fieldName))
+			}
+
+			if !ok {
+				*validationErrors = append(*validationErrors, documenttranslator.FieldError{
+					Struct: structName,
+					Field:  fieldName,
+					Rule:   rule.String(),
+					Value:  field.Interface(),
+				})
+			}
+		}
+
+		if err := validateChildren(field, fieldName, structName, validationErrors); err != nil {
+			return err
+		}
+	}
+
+	validateRecord(valueOf, prefix, structName, validationErrors)
+
+	return nil
+}
+
+// validateRecord appends the errors of records implementing documenttranslator.RecordValidator,
+// prefixing their fields with the path of the record.
+func validateRecord(valueOf reflect.Value, prefix string, structName string, validationErrors *documenttranslator.ValidationErrors) {
+	if valueOf.CanAddr() {
+		valueOf = valueOf.Addr()
+	}
+
+	recordValidator, ok := valueOf.Interface().(documenttranslator.RecordValidator)
+
+	if !ok {
+		return
+	}
+
+	for _, fieldError := range recordValidator.ValidateRecord() {
+		fieldError.Struct = structName
+		fieldError.Field = prefix + fieldError.Field
+		*validationErrors = append(*validationErrors, fieldError)
+	}
+}
+
+func validateChildren(field reflect.Value, fieldName string, structName string, validationErrors *documenttranslator.ValidationErrors) error {
+	switch {
+	case field.Kind() == reflect.Pointer && !field.IsNil():
+		return validateChildren(field.Elem(), fieldName, structName, validationErrors)
+	case field.Kind() == reflect.Struct && utils.IsNestedStruct(field.Type()):
+		return validateStruct(field, fieldName+".", structName, validationErrors)
+	case field.Kind() == reflect.Array || field.Kind() == reflect.Slice:
+		for index := 0; index < field.Len(); index++ {
+			if err := validateChildren(field.Index(index), fmt.Sprintf("%s[%d]", fieldName, index), structName, validationErrors); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rulesOf returns the rules of every field of a struct type, extracting them from the validate
+// tags on the first use of the type.
+func rulesOf(typeOf reflect.Type) []fieldRules {
+	if cached, ok := structRules.Load(typeOf); ok {
+		return cached.([]fieldRules)
+	}
+
+	fields := make([]fieldRules, typeOf.NumField())
+
+	for i := range fields {
+		fields[i].rules, fields[i].err = extractRules(typeOf.Field(i).Tag.Get("validate"))
+	}
+
+	cached, _ := structRules.LoadOrStore(typeOf, fields)
+
+	return cached.([]fieldRules)
+}
+
+// extractRules parses a validate tag, compiling the arguments of its rules.
+func extractRules(tag string) (rules []rule, err error) {
+	for _, rawRule := range strings.Split(tag, ";") {
+		if rawRule == "" {
+			continue
+		}
+
+		keyValuePair := strings.SplitN(rawRule, ":", 2)
+		rule := rule{Name: keyValuePair[0]}
+
+		if len(keyValuePair) > 1 {
+			rule.Value = keyValuePair[1]
+		}
+
+		switch rule.Name {
+		case "required", "cpf", "cnpj", "cpfcnpj":
+		case "oneof":
+			rule.options = strings.Split(rule.Value, ",")
+		case "regex":
+			if rule.pattern, err = regexp.Compile(rule.Value); err != nil {
+				return nil, err
+			}
+		case "min", "max":
+			if rule.limit, err = decimal.NewFromString(rule.Value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown validation rule %q", rule.Name)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+// check reports whether a field satisfies a rule. An error means the rule does not support the
+// field type.
+func check(rule rule, field reflect.Value) (bool, error) {
+	if rule.Name == "required" {
+		return !isEmpty(field), nil
+	}
+
+	// A zero number is a value an unfilled numeric column reads as, so oneof only skips
+	// absent fields and blank text.
+	if rule.Name == "oneof" && isBlank(field) || rule.Name != "oneof" && isEmpty(field) {
+		return true, nil
+	}
+
+	for field.Kind() == reflect.Pointer {
+		field = field.Elem()
+	}
+
+	switch rule.Name {
+	case "oneof":
+		text := text(field)
+
+		for _, option := range rule.options {
+			if text == option {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case "regex":
+		return rule.pattern.MatchString(text(field)), nil
+	case "min", "max":
+		number, ok := number(field)
+
+		if !ok {
+			return false, fmt.Errorf("rule %s does not support %s", rule, field.Type())
+		}
+
+		if rule.Name == "min" {
+			return number.GreaterThanOrEqual(rule.limit), nil
+		}
+
+		return number.LessThanOrEqual(rule.limit), nil
+	case "cpf", "cnpj", "cpfcnpj":
+		// Numeric columns left unfilled hold zeros rather than blanks.
+		if strings.Trim(text(field), "0") == "" {
+			return true, nil
+		}
+	}
+
+	switch rule.Name {
+	case "cpf":
+		return checkdigit.ValidCPF(text(field)), nil
+	case "cnpj":
+		return checkdigit.ValidCNPJ(text(field)), nil
+	case "cpfcnpj":
+		return checkdigit.ValidDocument(text(field)), nil
+	}
+
+	return true, nil
+}
+
+// isBlank reports whether a field is a nil pointer or blank text.
+func isBlank(field reflect.Value) bool {
+	if field.Kind() == reflect.Pointer {
+		return field.IsNil()
+	}
+
+	return field.Kind() == reflect.String && strings.TrimSpace(field.String()) == ""
+}
+
+func isEmpty(field reflect.Value) bool {
+	if field.Kind() == reflect.Pointer {
+		return field.IsNil()
+	}
+
+	switch value := field.Interface().(type) {
+	case time.Time:
+		return value.IsZero()
+	case decimal.Decimal:
+		return value.IsZero()
+	}
+
+	if field.Kind() == reflect.String {
+		return strings.TrimSpace(field.String()) == ""
+	}
+
+	return field.IsZero()
+}
+
+func text(field reflect.Value) string {
+	switch value := field.Interface().(type) {
+	case decimal.Decimal:
+		return value.String()
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(field.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10)
+	}
+
+	return fmt.Sprint(field.Interface())
+}
+
+// number returns the value of numeric fields and the length of strings.
+func number(field reflect.Value) (decimal.Decimal, bool) {
+	if value, ok := field.Interface().(decimal.Decimal); ok {
+		return value, true
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(field.Uint()), 0), true
+	case reflect.Float32, reflect.Float64:
+		return decimal.NewFromFloat(field.Float()), true
+	case reflect.String:
+		return decimal.NewFromInt(int64(len(strings.TrimSpace(field.String())))), true
+	}
+
+	return decimal.Decimal{}, false
+}
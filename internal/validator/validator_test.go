@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDocument struct {
+	Number string `validate:"required"`
+	Kind   int    `validate:"oneof:1,2"`
+}
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		name       string
+		value      interface{}
+		wantFields []string
+	}{
+		{
+			name: "valid struct",
+			value: struct {
+				Kind     int             `validate:"oneof:1,2"`
+				Code     string          `validate:"regex:^[A-Z]{2}$"`
+				Amount   decimal.Decimal `validate:"min:0;max:100"`
+				Name     string          `validate:"required;max:5"`
+				Document string          `validate:"cpfcnpj"`
+			}{
+				Kind:     2,
+				Code:     "AB",
+				Amount:   decimal.RequireFromString("99.99"),
+				Name:     "LIBER",
+				Document: "11.222.333/0001-81",
+			},
+		},
+		{
+			name: "blank values are accepted by every rule but required",
+			value: struct {
+				Kind     *int             `validate:"oneof:1,2"`
+				Code     string           `validate:"regex:^[A-Z]{2}$"`
+				Amount   *decimal.Decimal `validate:"min:1"`
+				Document string           `validate:"cpf"`
+			}{
+				Code: "   ",
+			},
+		},
+		{
+			name: "zero numbers are rejected by oneof",
+			value: struct {
+				DocumentKind int `validate:"oneof:1,2"`
+			}{},
+			wantFields: []string{"DocumentKind"},
+		},
+		{
+			name: "invalid fields",
+			value: struct {
+				Kind     int             `validate:"oneof:1,2"`
+				Code     string          `validate:"regex:^[A-Z]{2}$"`
+				Amount   decimal.Decimal `validate:"max:100"`
+				Name     string          `validate:"required"`
+				Cpf      string          `validate:"cpf"`
+				Cnpj     string          `validate:"cnpj"`
+				Document string          `validate:"cpfcnpj"`
+			}{
+				Kind:     7,
+				Code:     "A1",
+				Amount:   decimal.RequireFromString("100.01"),
+				Cpf:      "111.111.111-11",
+				Cnpj:     "11222333000182",
+				Document: "12345678900",
+			},
+			wantFields: []string{"Kind", "Code", "Amount", "Name", "Cpf", "Cnpj", "Document"},
+		},
+		{
+			name: "invalid nested, array and slice fields",
+			value: struct {
+				Document  testDocument
+				Documents [2]testDocument
+				Others    []*testDocument
+			}{
+				Document:  testDocument{Kind: 1},
+				Documents: [2]testDocument{{Number: "1", Kind: 1}, {Number: "2", Kind: 3}},
+				Others:    []*testDocument{nil, {Number: "3", Kind: 9}},
+			},
+			wantFields: []string{"Document.Number", "Documents[1].Kind", "Others[1].Kind"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+
+			if len(tt.wantFields) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+
+			var validationErrors documenttranslator.ValidationErrors
+			assert.True(t, errors.As(err, &validationErrors))
+
+			var fields []string
+			for _, fieldError := range validationErrors {
+				fields = append(fields, fieldError.Field)
+			}
+
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestValidateInvalidRule(t *testing.T) {
+	err := Validate(struct {
+		Kind int `validate:"unknown"`
+	}{})
+
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, documenttranslator.ErrValidation))
+}
+
+func TestValidateInvalidRuleArgument(t *testing.T) {
+	// Rule arguments are compiled with the rules, so blank fields report them too.
+	err := Validate(struct {
+		Code string `validate:"regex:[A-Z"`
+	}{})
+
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, documenttranslator.ErrValidation))
+
+	err = Validate(struct {
+		Amount int `validate:"max:ten"`
+	}{})
+
+	assert.Error(t, err)
+}
+
+func TestRulesOfCachesStructTypes(t *testing.T) {
+	type record struct {
+		Code string `validate:"required;regex:^[A-Z]{2}$"`
+	}
+
+	rules := rulesOf(reflect.TypeOf(record{}))
+
+	assert.Len(t, rules[0].rules, 2)
+	assert.Same(t, rules[0].rules[1].pattern, rulesOf(reflect.TypeOf(record{}))[0].rules[1].pattern)
+}
+
+type testAccount struct {
+	Account   string
+	AccountCd string
+}
+
+func (a testAccount) ValidateRecord() documenttranslator.ValidationErrors {
+	if a.AccountCd == "0" {
+		return nil
+	}
+
+	return documenttranslator.ValidationErrors{{Field: "AccountCd", Rule: "accountcd", Value: a.AccountCd}}
+}
+
+func TestValidateRecordValidator(t *testing.T) {
+	err := Validate(&struct {
+		Payer   testAccount
+		Vendors []testAccount
+	}{
+		Payer:   testAccount{Account: "1", AccountCd: "0"},
+		Vendors: []testAccount{{Account: "2", AccountCd: "9"}},
+	})
+
+	var validationErrors documenttranslator.ValidationErrors
+	assert.True(t, errors.As(err, &validationErrors))
+	assert.Equal(t, documenttranslator.ValidationErrors{{Struct: "", Field: "Vendors[0].AccountCd", Rule: "accountcd", Value: "9"}}, validationErrors)
+}
@@ -1,12 +1,17 @@
 package writer
 
 import (
+	"encoding"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	documenttranslator "github.com/libercapital/document-translator-go"
 	"github.com/libercapital/document-translator-go/internal/utils"
+	"github.com/libercapital/document-translator-go/internal/validator"
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
 	"github.com/shopspring/decimal"
 )
 
@@ -15,27 +20,117 @@ type fillType string
 const (
 	FillString fillType = "STRING"
 	FillNumber fillType = "NUMBER"
+	FillRaw    fillType = "RAW"
 )
 
 type serializerOpt struct {
-	Length int
-	Params []serializerParams // Params contains the serializer parameters for each field.
+	Length   int
+	KeepCase bool               // KeepCase writes text as informed instead of in upper case.
+	Params   []serializerParams // Params contains the serializer parameters for each field.
+	Limits   []occursLimit      // Limits holds the maximum length of each slice mapped to a repeating group.
+}
+
+type occursLimit struct {
+	Path   fieldPath
+	Occurs int
+}
+
+// fieldStep locates a struct field and, for repeating groups, one of its elements.
+type fieldStep struct {
+	Field   int
+	Element int // Element is the index inside a repeating group, -1 for plain fields.
+}
+
+type fieldPath []fieldStep
+
+func (p fieldPath) with(step fieldStep) fieldPath {
+	return append(append(fieldPath{}, p...), step)
+}
+
+// value returns the field located by the path, or false when a slice holds fewer elements.
+func (p fieldPath) value(structValue reflect.Value) (reflect.Value, bool) {
+	value := structValue
+
+	for _, step := range p {
+		value = value.Field(step.Field)
+
+		if step.Element >= 0 {
+			if step.Element >= value.Len() {
+				return reflect.Value{}, false
+			}
+
+			value = value.Index(step.Element)
+		}
+	}
+
+	return value, true
+}
+
+func (p fieldPath) name(structType reflect.Type) string {
+	var names []string
+
+	for _, step := range p {
+		field := structType.Field(step.Field)
+		name := field.Name
+		structType = field.Type
+
+		if step.Element >= 0 {
+			name = fmt.Sprintf("%s[%d]", name, step.Element)
+			structType = structType.Elem()
+		}
+
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ".")
 }
 
 type serializerParams struct {
+	Path        fieldPath // Path locates the field inside the struct.
 	FillType    fillType
 	Deliminator []int
 	TimeParse   string
+	TimePart    []int          // TimePart holds the delimiter indices of a second column receiving the end of a formatted time.
+	Location    *time.Location // Location specifies the time zone a time is formatted in.
 	Value       string
 	Align       string
-	Precision   int // Precision specifies the decimal precision for the field.
+	NilFill     fillType // NilFill specifies how a nil pointer field is written.
+	EmptyFill   fillType // EmptyFill specifies how a zero time or an empty string is written.
+	BoolTokens  []string // BoolTokens holds the true and false tokens of a boolean field.
+	Precision   int      // Precision specifies the decimal precision for the field.
+	PadZeros    bool     // PadZeros left pads text with zeros, reversing clearZeroLeft and lastDigits.
+	ClearZeros  bool     // ClearZeros marks clearZeroLeft fields, whose zeros on the left the parser strips.
+	LastDigits  int      // LastDigits is the number of trailing characters the parser keeps from the column.
+	PrefixFrom  []string // PrefixFrom holds the markers ending the prefix of a column split with splitAfter.
+	SplitAfter  []string // SplitAfter holds the markers the field value follows in its column.
+	Source      *string  // Source is the text of a derived field, which must read back from the written line.
 }
 
 func (s *serializerOpt) String() string {
 	var line = utils.EmptyArray(s.Length)
 	for _, param := range s.Params {
+		// Empty text leaves the blank line untouched, so it must not clobber
+		// columns already written by another field sharing the same range.
+		if param.Value == "" && param.FillType == FillString {
+			continue
+		}
+
 		length := (param.Deliminator[1] - param.Deliminator[0] + 1)
-		var data = fillValue(param.Value, length, param.FillType, param.Align)
+		value := param.Value
+
+		if len(param.TimePart) > 0 {
+			timePartLength := param.TimePart[1] - param.TimePart[0] + 1
+			timePartValue := ""
+
+			if len(value) > length {
+				value, timePartValue = value[:length], value[length:]
+			}
+
+			var data = fillValue(timePartValue, timePartLength, param.FillType, param.Align, s.KeepCase)
+			copy(line[param.TimePart[0]:], data[:timePartLength])
+		}
+
+		var data = fillValue(value, length, param.FillType, param.Align, s.KeepCase)
 		copy(line[param.Deliminator[0]:], data[:length])
 	}
 	return string(line)
@@ -43,108 +138,469 @@ func (s *serializerOpt) String() string {
 
 func extractValues(structValue reflect.Value, opt *serializerOpt) error {
 
-	for i := 0; i < structValue.NumField(); i++ {
-		opt.Params[i].Value = getValue(&opt.Params[i], structValue.Field(i))
+	for _, limit := range opt.Limits {
+		if value, ok := limit.Path.value(structValue); ok && value.Len() > limit.Occurs {
+			return wraperrors.NewErrWrap(documenttranslator.ErrTooManyOccurrences, fmt.Errorf("at struct %s and field %s: %d elements for occurs:%d", structValue.Type(), limit.Path.name(structValue.Type()), value.Len(), limit.Occurs))
+		}
+	}
+
+	for i := range opt.Params {
+		field, ok := opt.Params[i].Path.value(structValue)
+		if !ok {
+			opt.Params[i].Value, opt.Params[i].FillType = "", FillString
+			continue
+		}
+
+		value, err := getValue(&opt.Params[i], field)
+		if err != nil {
+			return wraperrors.NewErrWrap(err, fmt.Errorf("at struct %s and field %s", structValue.Type(), opt.Params[i].Path.name(structValue.Type())))
+		}
+		opt.Params[i].Value = value
 	}
+
+	joinSplitColumns(opt.Params)
+
 	return nil
 
 }
 
+// joinSplitColumns writes a prefixFrom field and the splitAfter field sharing its columns
+// as a single value: the prefix followed by the remainder when the prefix ends with one of
+// its markers, the remainder alone otherwise, since both fields then hold the whole column.
+func joinSplitColumns(params []serializerParams) {
+	for i := range params {
+		if len(params[i].SplitAfter) == 0 {
+			continue
+		}
+
+		for j := range params {
+			if len(params[j].PrefixFrom) == 0 || !reflect.DeepEqual(params[j].Deliminator, params[i].Deliminator) {
+				continue
+			}
+
+			for _, marker := range params[j].PrefixFrom {
+				if strings.HasSuffix(params[j].Value, marker) {
+					params[i].Value = params[j].Value + params[i].Value
+					break
+				}
+			}
+
+			params[j].Value = ""
+		}
+	}
+}
+
 func extractTags(structTagged reflect.Type) (serializerOpt serializerOpt, err error) {
-	serializerOpt.Params = make([]serializerParams, structTagged.NumField())
+	return extractTagsAt(structTagged, nil, 0)
+}
 
+// extractTagsAt extracts the tags of a struct whose columns start at offset, flattening
+// nested and embedded struct fields into their own params. As in the parser, nested structs
+// have absolute columns unless an offset tag places them relative to their parent.
+func extractTagsAt(structTagged reflect.Type, path fieldPath, offset int) (serializerOpt serializerOpt, err error) {
 	for i := 0; i < structTagged.NumField(); i++ {
 		f := structTagged.Field(i)
+		param := serializerParams{Path: path.with(fieldStep{Field: i, Element: -1}), Precision: 2}
+		fieldOffset := 0
+		occurs := 0
 
 		translatorTags := strings.Split(f.Tag.Get("translator"), ";")
 
 		for _, rule := range translatorTags {
-			parts := strings.Split(rule, ";")
-			defaultPrecision := true
-
-			for _, part := range parts {
-				keyValuePair := strings.Split(part, ":")
-				key := keyValuePair[0]
-				value := keyValuePair[1]
-
-				switch key {
-				case "part":
-					convertToInt, err := utils.ConvertStringToIntSlice(strings.Split(value, "..")...)
-
-					if err != nil {
-						return serializerOpt, err
-					}
-
-					serializerOpt.Params[i].Deliminator = convertToInt
-				case "timeParse":
-					serializerOpt.Params[i].TimeParse = value
-				case "align":
-					serializerOpt.Params[i].Align = value
-				case "precision":
-					precision, err := strconv.Atoi(keyValuePair[1])
-					if err != nil {
-						return serializerOpt, err
-					}
-					serializerOpt.Params[i].Precision = precision
-					defaultPrecision = false
+			keyValuePair := strings.SplitN(rule, ":", 2)
+			key := keyValuePair[0]
+			value := ""
+
+			if len(keyValuePair) > 1 {
+				value = keyValuePair[1]
+			}
+
+			switch key {
+			case "part":
+				convertToInt, err := utils.ConvertStringToIntSlice(strings.Split(value, "..")...)
+
+				if err != nil {
+					return serializerOpt, err
+				}
+
+				param.Deliminator = addOffset(convertToInt, offset)
+			case "offset":
+				nestedOffset, err := strconv.Atoi(value)
+
+				if err != nil {
+					return serializerOpt, err
+				}
+
+				fieldOffset = offset + nestedOffset
+			case "occurs":
+				occurs, err = strconv.Atoi(value)
+
+				if err != nil {
+					return serializerOpt, err
+				}
+			case "timeParse":
+				param.TimeParse = value
+			case "timePart":
+				convertToInt, err := utils.ConvertStringToIntSlice(strings.Split(value, "..")...)
+
+				if err != nil {
+					return serializerOpt, err
+				}
+
+				param.TimePart = addOffset(convertToInt, offset)
+			case "tz":
+				location, err := utils.LoadLocation(value)
+
+				if err != nil {
+					return serializerOpt, err
+				}
+
+				param.Location = location
+			case "bool":
+				tokens := strings.Split(value, ",")
+
+				if len(tokens) != 2 {
+					return serializerOpt, fmt.Errorf("bool option %q must have a true and a false token", value)
+				}
+
+				param.BoolTokens = tokens
+			case "align":
+				param.Align = value
+			case "clearZeroLeft":
+				param.PadZeros = true
+				param.ClearZeros = true
+			case "lastDigits":
+				lastDigits, err := strconv.Atoi(value)
+				if err != nil {
+					return serializerOpt, err
+				}
+				param.PadZeros = true
+				param.LastDigits = lastDigits
+			case "prefixFrom":
+				param.PrefixFrom = strings.Split(value, ",")
+			case "splitAfter":
+				param.SplitAfter = strings.Split(value, ",")
+			case "nil", "empty":
+				var fill fillType
+
+				switch value {
+				case "zeros":
+					fill = FillNumber
+				case "spaces":
+					fill = FillString
+				default:
+					return serializerOpt, fmt.Errorf("invalid %s option %q", key, value)
 				}
+
+				if key == "nil" {
+					param.NilFill = fill
+				} else {
+					param.EmptyFill = fill
+				}
+			case "precision":
+				precision, err := strconv.Atoi(value)
+				if err != nil {
+					return serializerOpt, err
+				}
+				param.Precision = precision
+			}
+		}
+
+		if occurs > 0 {
+			occurrences, err := extractOccurrences(f, path.with(fieldStep{Field: i, Element: -1}), param, occurs)
+
+			if err != nil {
+				return serializerOpt, err
 			}
 
-			if defaultPrecision {
-				serializerOpt.Params[i].Precision = 2
+			serializerOpt.Params = append(serializerOpt.Params, occurrences.Params...)
+			serializerOpt.Limits = append(serializerOpt.Limits, occurrences.Limits...)
+			continue
+		}
+
+		if len(param.Deliminator) > 0 {
+			serializerOpt.Params = append(serializerOpt.Params, param)
+			continue
+		}
+
+		if utils.IsNestedStruct(f.Type) {
+			nested, err := extractTagsAt(f.Type, param.Path, fieldOffset)
+
+			if err != nil {
+				return serializerOpt, err
 			}
+
+			serializerOpt.Params = append(serializerOpt.Params, nested.Params...)
+			serializerOpt.Limits = append(serializerOpt.Limits, nested.Limits...)
 		}
 	}
 
 	return
 }
 
+// extractOccurrences splits the columns of a repeating group field into occurs elements of
+// equal width, mirroring the parser layout of arrays and slices.
+func extractOccurrences(field reflect.StructField, fieldPath fieldPath, param serializerParams, occurs int) (serializerOpt serializerOpt, err error) {
+	if field.Type.Kind() != reflect.Array && field.Type.Kind() != reflect.Slice {
+		return serializerOpt, fmt.Errorf("field %s: occurs requires an array or slice, got %s", field.Name, field.Type)
+	}
+
+	if field.Type.Kind() == reflect.Array && field.Type.Len() != occurs {
+		return serializerOpt, fmt.Errorf("field %s: occurs:%d does not match array length %d", field.Name, occurs, field.Type.Len())
+	}
+
+	if len(param.Deliminator) != 2 {
+		return serializerOpt, fmt.Errorf("field %s: occurs requires a part", field.Name)
+	}
+
+	width := param.Deliminator[1] - param.Deliminator[0] + 1
+
+	if width%occurs != 0 {
+		return serializerOpt, fmt.Errorf("field %s: part %d..%d cannot be split in %d occurrences", field.Name, param.Deliminator[0], param.Deliminator[1], occurs)
+	}
+
+	if field.Type.Kind() == reflect.Slice {
+		serializerOpt.Limits = append(serializerOpt.Limits, occursLimit{Path: fieldPath, Occurs: occurs})
+	}
+
+	width /= occurs
+	parent := fieldPath[:len(fieldPath)-1]
+
+	for index := 0; index < occurs; index++ {
+		start := param.Deliminator[0] + index*width
+		elementPath := parent.with(fieldStep{Field: fieldPath[len(fieldPath)-1].Field, Element: index})
+
+		if utils.IsNestedStruct(field.Type.Elem()) {
+			nested, err := extractTagsAt(field.Type.Elem(), elementPath, start)
+
+			if err != nil {
+				return serializerOpt, err
+			}
+
+			serializerOpt.Params = append(serializerOpt.Params, nested.Params...)
+			serializerOpt.Limits = append(serializerOpt.Limits, nested.Limits...)
+			continue
+		}
+
+		element := param
+		element.Path = elementPath
+		element.Deliminator = []int{start, start + width - 1}
+		serializerOpt.Params = append(serializerOpt.Params, element)
+	}
+
+	return serializerOpt, nil
+}
+
+func addOffset(deliminator []int, offset int) []int {
+	for index := range deliminator {
+		deliminator[index] += offset
+	}
+
+	return deliminator
+}
+
 func structToString(value interface{}, length int) (string, error) {
+	return structToLine(value, length, false)
+}
 
+// structToLine writes a struct as a line of length bytes, keeping the case of its text when keepCase is set.
+func structToLine(value interface{}, length int, keepCase bool) (string, error) {
 	serializerOpts, err := extractTags(reflect.TypeOf(value))
 	if err != nil {
 		return "", err
 	}
 	serializerOpts.Length = length
+	serializerOpts.KeepCase = keepCase
 
 	if err := extractValues(reflect.ValueOf(value), &serializerOpts); err != nil {
 		return "", err
 	}
 
-	return serializerOpts.String(), nil
+	line := serializerOpts.String()
+
+	if err := checkDerivedFields(line, reflect.TypeOf(value), serializerOpts.Params, keepCase); err != nil {
+		return "", err
+	}
+
+	return line, nil
 
 }
 
-func getValue(param *serializerParams, structValue reflect.Value) string {
+func getValue(param *serializerParams, structValue reflect.Value) (string, error) {
+	if structValue.Kind() == reflect.Pointer {
+		if structValue.IsNil() {
+			param.FillType = FillString
 
-	switch structValue.Interface().(type) {
-	case int, int32, int64:
-		param.FillType = FillNumber
-		return strconv.FormatInt(structValue.Int(), 10)
+			if param.NilFill != "" {
+				param.FillType = param.NilFill
+			}
 
-	case string:
-		param.FillType = FillString
-		return structValue.String()
+			return "", nil
+		}
+
+		return getValue(param, structValue.Elem())
+	}
+
+	if marshaler, ok := addressable(structValue).Interface().(documenttranslator.FieldMarshaler); ok {
+		width := param.Deliminator[1] - param.Deliminator[0] + 1
+		data, err := marshaler.MarshalFixed(width)
+		if err != nil {
+			return "", err
+		}
+		if len(data) > width {
+			return "", wraperrors.NewErrWrap(documenttranslator.ErrFieldOverflow, fmt.Errorf("got %d bytes for %d columns", len(data), width))
+		}
+		param.FillType = FillRaw
+		return string(data), nil
+	}
 
+	switch structValue.Interface().(type) {
 	case time.Time:
 		param.FillType = FillString
 		timeValue := structValue.Interface().(time.Time)
 		if timeValue.IsZero() {
-			return ""
+			// Layouts filling absent dates with zeros tag them empty:zeros.
+			if param.EmptyFill != "" {
+				param.FillType = param.EmptyFill
+			}
+			return "", nil
 		}
-		return timeValue.Format(param.TimeParse)
+		if param.Location != nil {
+			timeValue = timeValue.In(param.Location)
+		}
+		return timeValue.Format(param.TimeParse), nil
 
 	case decimal.Decimal:
 		param.FillType = FillNumber
 		decimal := structValue.Interface().(decimal.Decimal)
-		return strings.ReplaceAll(decimal.StringFixed(int32(param.Precision)), ".", "")
+		return strings.ReplaceAll(decimal.StringFixed(int32(param.Precision)), ".", ""), nil
+	}
+
+	if marshaler, ok := addressable(structValue).Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		param.FillType = FillString
+		return string(text), nil
+	}
+
+	switch structValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		param.FillType = FillNumber
+		return strconv.FormatInt(structValue.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		param.FillType = FillNumber
+		return strconv.FormatUint(structValue.Uint(), 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		param.FillType = FillNumber
+		float := strconv.FormatFloat(structValue.Float(), 'f', param.Precision, structValue.Type().Bits())
+		return strings.ReplaceAll(float, ".", ""), nil
+
+	case reflect.Bool:
+		param.FillType = FillString
+		trueToken, falseToken := "1", "0"
+		if len(param.BoolTokens) == 2 {
+			trueToken, falseToken = param.BoolTokens[0], param.BoolTokens[1]
+		}
+		if structValue.Bool() {
+			return trueToken, nil
+		}
+		return falseToken, nil
+
+	case reflect.String:
+		param.FillType = FillString
+		if param.derived() {
+			source := structValue.String()
+			param.Source = &source
+		}
+		if param.PadZeros && param.Align != "right" && (structValue.Len() > 0 || param.EmptyFill == "") {
+			param.FillType = FillNumber
+		}
+		return structValue.String(), nil
+	}
+
+	return "", nil
+}
+
+// derived reports whether the parser transforms the column text before assigning it to the field.
+func (p serializerParams) derived() bool {
+	return p.PadZeros || len(p.PrefixFrom) > 0 || len(p.SplitAfter) > 0
+}
+
+// readBack returns the text the parser assigns to a derived field from its written column.
+func (p serializerParams) readBack(column string) string {
+	for _, marker := range p.PrefixFrom {
+		if index := strings.Index(column, marker); index != -1 {
+			return strings.TrimSpace(column[:index+len(marker)])
+		}
+	}
+
+	for _, marker := range p.SplitAfter {
+		if index := strings.Index(column, marker); index != -1 {
+			return strings.TrimSpace(column[index+len(marker):])
+		}
+	}
+
+	if p.ClearZeros {
+		return strings.TrimSpace(strings.TrimLeft(column, "0"))
+	}
+
+	if p.LastDigits > 0 && p.LastDigits <= len(column) {
+		return strings.TrimSpace(column[len(column)-p.LastDigits:])
+	}
+
+	return strings.TrimSpace(column)
+}
+
+// checkDerivedFields rejects derived fields whose value the parser would not read back from
+// the written line, e.g. a clearZeroLeft value with leading zeros or a prefixFrom value not
+// ending with one of its markers, instead of silently writing a different value.
+func checkDerivedFields(line string, structType reflect.Type, params []serializerParams, keepCase bool) error {
+	for _, param := range params {
+		if param.Source == nil {
+			continue
+		}
+
+		source := *param.Source
+
+		if !keepCase {
+			source = strings.ToUpper(source)
+		}
+
+		read := param.readBack(line[param.Deliminator[0] : param.Deliminator[1]+1])
+
+		if read != source {
+			return wraperrors.NewErrWrap(documenttranslator.ErrIrreversibleField, fmt.Errorf("at struct %s and field %s: %q reads back as %q", structType, param.Path.name(structType), source, read))
+		}
 	}
 
-	return ""
+	return nil
+}
+
+// addressable returns an addressable copy of value, so methods with pointer receivers are found.
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value.Addr()
+	}
+
+	copied := reflect.New(value.Type())
+	copied.Elem().Set(value)
+
+	return copied
 }
 
-func fillValue(value string, length int, fillType fillType, align string) []byte {
-	value = strings.ToUpper(value)
+func fillValue(value string, length int, fillType fillType, align string, keepCase bool) []byte {
+	if fillType == FillRaw {
+		return []byte(value + strings.Repeat(" ", length-len(value)))
+	}
+
+	if !keepCase {
+		value = strings.ToUpper(value)
+	}
+
 	if len(value) > length {
 		value = value[:length]
 	}
@@ -165,6 +621,22 @@ func fillValue(value string, length int, fillType fillType, align string) []byte
 	return []byte(value + padding)
 }
 
+// Marshal validates a struct against its `validate` tags and writes it as a fixed-width line of length bytes.
 func Marshal(value interface{}, length int) (string, error) {
+	if err := validator.Validate(value); err != nil {
+		return "", err
+	}
+
 	return structToString(value, length)
 }
+
+// MarshalKeepCase validates and writes a struct as Marshal does, but writes its text as
+// informed instead of in upper case, so the records parsed from a file write back the very
+// same line.
+func MarshalKeepCase(value interface{}, length int) (string, error) {
+	if err := validator.Validate(value); err != nil {
+		return "", err
+	}
+
+	return structToLine(value, length, true)
+}
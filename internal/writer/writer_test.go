@@ -2,9 +2,11 @@ package writer
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	documenttranslator "github.com/libercapital/document-translator-go"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,6 +70,70 @@ func Test_serializerOpts_String(t *testing.T) {
 	}
 }
 
+type registerType string
+
+type testFiscalDocument struct {
+	Number string          `translator:"part:0..2"`
+	Value  decimal.Decimal `translator:"part:3..6;precision:2"`
+}
+
+func TestMarshalTooManyOccurrences(t *testing.T) {
+	_, err := Marshal(struct {
+		Documents []testFiscalDocument `translator:"part:0..6;occurs:1"`
+	}{
+		Documents: []testFiscalDocument{{Number: "1"}, {Number: "2"}},
+	}, 7)
+
+	assert.ErrorIs(t, err, documenttranslator.ErrTooManyOccurrences)
+}
+
+type testControl struct {
+	BankCode    string `translator:"part:0..2"`
+	BatchNumber int    `translator:"part:3..6"`
+}
+
+type testBankAccount struct {
+	Agency    int    `translator:"part:0..4"`
+	AgencyCd  string `translator:"part:5..5"`
+	Account   int    `translator:"part:6..9"`
+	AccountCd int    `translator:"part:10..10"`
+}
+
+type testAccountHolder struct {
+	testControl
+	Name    string          `translator:"part:0..1"`
+	Account testBankAccount `translator:"offset:2"`
+}
+
+type fixedDocument struct {
+	Root    string
+	Control string
+}
+
+func (d *fixedDocument) MarshalFixed(width int) ([]byte, error) {
+	return []byte(d.Root + d.Control), nil
+}
+
+type textZipCode string
+
+func (z textZipCode) MarshalText() ([]byte, error) {
+	return []byte(strings.ReplaceAll(string(z), "-", "")), nil
+}
+
+type overflowField struct{}
+
+func (overflowField) MarshalFixed(width int) ([]byte, error) {
+	return []byte(strings.Repeat("X", width+1)), nil
+}
+
+func TestMarshalFieldOverflow(t *testing.T) {
+	_, err := Marshal(struct {
+		Field overflowField `translator:"part:0..1"`
+	}{}, 2)
+
+	assert.ErrorIs(t, err, documenttranslator.ErrFieldOverflow)
+}
+
 func Test_structToString(t *testing.T) {
 	date, _ := time.Parse("02012006", "10102023")
 	var tests = []struct {
@@ -139,6 +205,28 @@ func Test_structToString(t *testing.T) {
 			wantErr: nil,
 			want:    "        ", // Esperado formato da data zero
 		},
+		{
+			name: "successful struct to string with zero date filled with zeros",
+			value: struct {
+				ZeroDate time.Time `translator:"part:0..7;timeParse:02012006;empty:zeros"`
+			}{
+				ZeroDate: time.Time{},
+			},
+			length:  8,
+			wantErr: nil,
+			want:    "00000000",
+		},
+		{
+			name: "successful struct to string with zero date ignoring the nil fill of pointers",
+			value: struct {
+				ZeroDate time.Time `translator:"part:0..7;timeParse:02012006;nil:zeros"`
+			}{
+				ZeroDate: time.Time{},
+			},
+			length:  8,
+			wantErr: nil,
+			want:    "        ",
+		},
 		{
 			name: "successful struct to string with value with precision",
 			value: struct {
@@ -153,6 +241,153 @@ func Test_structToString(t *testing.T) {
 			wantErr: nil,
 			want:    "00064",
 		},
+		{
+			name: "successful struct to string with time split in two columns and time zone",
+			value: struct {
+				Date     time.Time `translator:"part:0..7;timePart:10..15;timeParse:02012006150405;tz:America/Sao_Paulo"`
+				Filler   string    `translator:"part:8..9"`
+				Shared   string    `translator:"part:16..19"`
+				Overlaps time.Time `translator:"part:16..19;timeParse:2006"`
+			}{
+				Date:   time.Date(2024, time.September, 16, 10, 13, 29, 0, time.UTC),
+				Filler: "XX",
+				Shared: "KEEP",
+			},
+			length:  20,
+			wantErr: nil,
+			want:    "16092024XX071329KEEP",
+		},
+		{
+			name: "successful struct to string with nil and informed pointers",
+			value: struct {
+				Date       *time.Time       `translator:"part:0..7;timeParse:02012006"`
+				ZeroDate   *time.Time       `translator:"part:8..15;timeParse:02012006;nil:zeros"`
+				Amount     *decimal.Decimal `translator:"part:16..19;precision:2;nil:zeros"`
+				Number     *int             `translator:"part:20..22"`
+				Text       *string          `translator:"part:23..25"`
+				TextFilled *string          `translator:"part:26..28"`
+			}{
+				Number:     func() *int { number := 7; return &number }(),
+				TextFilled: func() *string { text := "abc"; return &text }(),
+			},
+			length:  29,
+			wantErr: nil,
+			want:    "        000000000000007   ABC",
+		},
+		{
+			name: "successful struct to string with named types, unsigned, floats and booleans",
+			value: struct {
+				Register registerType `translator:"part:0..0"`
+				Small    int16        `translator:"part:1..3"`
+				Unsigned uint         `translator:"part:4..6"`
+				Rate     float64      `translator:"part:7..10;precision:2"`
+				Flag     bool         `translator:"part:11..11;bool:S,N"`
+				Default  bool         `translator:"part:12..12"`
+			}{
+				Register: "2",
+				Small:    123,
+				Unsigned: 45,
+				Rate:     12.34,
+				Flag:     true,
+			},
+			length:  13,
+			wantErr: nil,
+			want:    "21230451234S0",
+		},
+		{
+			name: "successful struct to string with field marshalers",
+			value: struct {
+				Document fixedDocument `translator:"part:0..5"`
+				ZipCode  textZipCode   `translator:"part:6..14"`
+			}{
+				Document: fixedDocument{Root: "12", Control: "ab"},
+				ZipCode:  "01310-100",
+			},
+			length:  15,
+			wantErr: nil,
+			want:    "12ab  01310100 ",
+		},
+		{
+			name: "successful struct to string with embedded and nested structs",
+			value: struct {
+				testControl
+				RegistryKind int             `translator:"part:7..7"`
+				Account      testBankAccount `translator:"offset:8"`
+				Ignored      string
+			}{
+				testControl:  testControl{BankCode: "353", BatchNumber: 1},
+				RegistryKind: 1,
+				Account:      testBankAccount{Agency: 1893, AgencyCd: "7", Account: 12},
+				Ignored:      "ignored",
+			},
+			length:  20,
+			wantErr: nil,
+			want:    "3530001101893700120 ",
+		},
+		{
+			name: "successful struct to string with absolute columns nested in an offset struct",
+			value: struct {
+				Holder testAccountHolder `translator:"offset:10"`
+			}{
+				Holder: testAccountHolder{
+					testControl: testControl{BankCode: "353", BatchNumber: 1},
+					Name:        "XY",
+					Account:     testBankAccount{Agency: 18937, AgencyCd: "0", Account: 1234, AccountCd: 5},
+				},
+			},
+			length:  23,
+			wantErr: nil,
+			want:    "3530001   XY18937012345",
+		},
+		{
+			name: "successful struct to string with repeating groups",
+			value: struct {
+				Kind      string                `translator:"part:0..0"`
+				Documents []testFiscalDocument  `translator:"part:1..21;occurs:3"`
+				Buckets   [2]decimal.Decimal    `translator:"part:22..27;occurs:2;precision:1"`
+				Fixed     [1]testFiscalDocument `translator:"part:28..34;occurs:1"`
+			}{
+				Kind: "Y",
+				Documents: []testFiscalDocument{
+					{Number: "1", Value: decimal.RequireFromString("1.23")},
+					{Number: "2", Value: decimal.RequireFromString("4.56")},
+				},
+				Buckets: [2]decimal.Decimal{decimal.Zero, decimal.RequireFromString("12.3")},
+			},
+			length:  35,
+			wantErr: nil,
+			want:    "Y1  01232  0456       000123   0000",
+		},
+		{
+			name: "successful struct to string with zeros cleared on parse",
+			value: struct {
+				Account     string `translator:"part:0..7;clearZeroLeft"`
+				AccountCd   string `translator:"part:8..11;lastDigits:2"`
+				Empty       string `translator:"part:12..15;clearZeroLeft"`
+				EmptySpaces string `translator:"part:16..19;clearZeroLeft;empty:spaces"`
+				Aligned     string `translator:"part:20..25;clearZeroLeft;align:right"`
+			}{
+				Account:   "73280",
+				AccountCd: "07",
+				Aligned:   "1234",
+			},
+			length:  26,
+			wantErr: nil,
+			want:    "0007328000070000      1234",
+		},
+		{
+			name: "successful struct to string with a column split by a marker",
+			value: struct {
+				Prefix string `translator:"part:0..9;prefixFrom:PS,BD"`
+				Number string `translator:"part:0..9;splitAfter:PS,BD"`
+			}{
+				Prefix: "0033PS",
+				Number: "1234",
+			},
+			length:  10,
+			wantErr: nil,
+			want:    "0033PS1234",
+		},
 		{
 			name: "error when struct to string with wrong precision",
 			value: struct {
@@ -182,3 +417,111 @@ func Test_structToString(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalValidation(t *testing.T) {
+	_, err := Marshal(struct {
+		DocumentKind int `translator:"part:0..0" validate:"oneof:1,2"`
+	}{DocumentKind: 3}, 1)
+
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+}
+
+func TestMarshalKeepCase(t *testing.T) {
+	value := struct {
+		BankName string `translator:"part:0..14"`
+		Prefix   string `translator:"part:15..24;prefixFrom:PS"`
+		Number   string `translator:"part:15..24;splitAfter:PS"`
+	}{BankName: "Banco Santander", Prefix: "abcPS", Number: "12"}
+
+	line, err := MarshalKeepCase(value, 25)
+	assert.NoError(t, err)
+	assert.Equal(t, "Banco SantanderabcPS12   ", line)
+
+	line, err = Marshal(value, 25)
+	assert.NoError(t, err)
+	assert.Equal(t, "BANCO SANTANDERABCPS12   ", line)
+}
+
+func TestMarshalIrreversibleDerivedFields(t *testing.T) {
+	type clearedZeros struct {
+		Account string `translator:"part:0..7;clearZeroLeft"`
+	}
+	type lastDigits struct {
+		BankCode string `translator:"part:0..4;lastDigits:3"`
+	}
+	type splitColumn struct {
+		Prefix string `translator:"part:0..9;prefixFrom:PS,BD"`
+		Number string `translator:"part:0..9;splitAfter:PS,BD"`
+	}
+
+	var tests = []struct {
+		name    string
+		value   interface{}
+		length  int
+		wantErr error
+		want    string
+	}{
+		{
+			name:   "zeros on the left are padded back",
+			value:  clearedZeros{Account: "73280"},
+			length: 8,
+			want:   "00073280",
+		},
+		{
+			name:    "leading zeros would be cleared when parsed",
+			value:   clearedZeros{Account: "073280"},
+			length:  8,
+			wantErr: documenttranslator.ErrIrreversibleField,
+		},
+		{
+			name:   "last digits are padded back",
+			value:  lastDigits{BankCode: "033"},
+			length: 5,
+			want:   "00033",
+		},
+		{
+			name:    "more digits than the parser keeps",
+			value:   lastDigits{BankCode: "0033"},
+			length:  5,
+			wantErr: documenttranslator.ErrIrreversibleField,
+		},
+		{
+			name:    "fewer digits than the parser keeps",
+			value:   lastDigits{BankCode: "33"},
+			length:  5,
+			wantErr: documenttranslator.ErrIrreversibleField,
+		},
+		{
+			name:   "prefix and remainder are joined back",
+			value:  splitColumn{Prefix: "0033PS", Number: "1234"},
+			length: 10,
+			want:   "0033PS1234",
+		},
+		{
+			name:    "prefix without its marker",
+			value:   splitColumn{Prefix: "0033", Number: "1234"},
+			length:  10,
+			wantErr: documenttranslator.ErrIrreversibleField,
+		},
+		{
+			name:    "remainder holding a marker",
+			value:   splitColumn{Prefix: "0033BD", Number: "12PS"},
+			length:  10,
+			wantErr: documenttranslator.ErrIrreversibleField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := Marshal(tt.value, tt.length)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, line)
+		})
+	}
+}
@@ -0,0 +1,533 @@
+package documenttranslator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var ErrUnknownField = errors.New("field does not belong to the record layout")
+
+// FieldNaming selects the keys of the fields exported by ExportJSONLines.
+type FieldNaming int
+
+const (
+	FieldNamingGo        FieldNaming = iota // FieldNamingGo keeps the Go field names, e.g. "BaseDate".
+	FieldNamingSnakeCase                    // FieldNamingSnakeCase converts the Go field names, e.g. "base_date".
+	FieldNamingSpec                         // FieldNamingSpec uses the `spec` tag, e.g. "data_base", or the snake_case name of untagged fields.
+)
+
+// JSONLinesOptions configures ExportJSONLines and ImportJSONLines.
+type JSONLinesOptions struct {
+	Naming FieldNaming
+}
+
+// jsonLine is a record exported by ExportJSONLines.
+type jsonLine struct {
+	RecordType string     `json:"record_type"`
+	LineNumber int        `json:"line_number"`
+	Fields     jsonObject `json:"fields"`
+}
+
+// importedLine is a record read by ImportJSONLines. Its line_number, if any, is ignored.
+type importedLine struct {
+	RecordType string                     `json:"record_type"`
+	Fields     map[string]json.RawMessage `json:"fields"`
+}
+
+// jsonField is a key and value of a jsonObject.
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// jsonObject is a JSON object keeping its keys in the order of the struct fields.
+type jsonObject []jsonField
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+
+	for i, field := range o {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// ExportJSONLines reads every line of a file of a registered format and writes each record as
+// a JSON object on its own line, holding its record_type, the kind of the line in the format
+// Records, its line_number and its fields. Decimals are written as strings with the precision
+// of their columns, times in RFC 3339, zero times and nil pointers as null.
+//
+// Parameters:
+//   - format: The format of the file.
+//   - reader: The file contents. Blank lines and CRLF line endings are accepted.
+//   - writer: Receives the JSON Lines.
+//   - options: The naming of the field keys.
+//
+// Returns:
+//   - error: ErrUnregisteredFormat for unknown formats, or the error of the first line not parsed, with its line number.
+//
+// Example:
+//
+//	err := ExportJSONLines(FormatBradesco600, file, output, JSONLinesOptions{Naming: FieldNamingSpec})
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ExportJSONLines(format Format, reader io.Reader, writer io.Writer, options JSONLinesOptions) error {
+	definition, ok := Lookup(format)
+
+	if !ok {
+		return wraperrors.NewErrWrap(ErrUnregisteredFormat, fmt.Errorf("format %q", format))
+	}
+
+	output := bufio.NewWriter(writer)
+	encoder := json.NewEncoder(output)
+	encoder.SetEscapeHTML(false)
+
+	err := definition.eachRecord(reader, func(lineNumber int, kind string, record Record) error {
+		fields, err := exportStruct(reflect.ValueOf(record), options.Naming)
+
+		if err != nil {
+			return err
+		}
+
+		return encoder.Encode(jsonLine{RecordType: kind, LineNumber: lineNumber, Fields: fields})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return output.Flush()
+}
+
+// exportStruct converts the fields of a record tagged with `translator` to a jsonObject.
+func exportStruct(structValue reflect.Value, naming FieldNaming) (jsonObject, error) {
+	for structValue.Kind() == reflect.Pointer || structValue.Kind() == reflect.Interface {
+		structValue = structValue.Elem()
+	}
+
+	structType := structValue.Type()
+	object := jsonObject{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		if embeddedBlock(field, tag) {
+			block, err := exportStruct(structValue.Field(i), naming)
+
+			if err != nil {
+				return nil, err
+			}
+
+			object = append(object, block...)
+			continue
+		}
+
+		value, err := exportValue(structValue.Field(i), tagPrecision(tag), naming)
+
+		if err != nil {
+			return nil, fmt.Errorf("at field %s: %w", field.Name, err)
+		}
+
+		object = append(object, jsonField{key: fieldKey(field, naming), value: value})
+	}
+
+	return object, nil
+}
+
+// exportValue converts a field to a value encoding/json writes as this package exports it.
+// precision is the precision tag of decimal fields, or -1.
+func exportValue(value reflect.Value, precision int, naming FieldNaming) (interface{}, error) {
+	switch field := value.Interface().(type) {
+	case time.Time:
+		if field.IsZero() {
+			return nil, nil
+		}
+
+		return field.Format(time.RFC3339), nil
+	case decimal.Decimal:
+		if precision < 0 {
+			return field.String(), nil
+		}
+
+		return field.StringFixed(int32(precision)), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return exportValue(value.Elem(), precision, naming)
+	case reflect.Struct:
+		return exportStruct(value, naming)
+	case reflect.Array, reflect.Slice:
+		values := make([]interface{}, value.Len())
+
+		for i := range values {
+			element, err := exportValue(value.Index(i), precision, naming)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[i] = element
+		}
+
+		return values, nil
+	}
+
+	return value.Interface(), nil
+}
+
+// ImportJSONLines reads records in the JSON Lines written by ExportJSONLines, one object per
+// line holding its record_type and fields, and builds a complete document of a registered
+// format. Fields left out or null keep their zero value, then take the defaults of the layout
+// with FillDefaults. Each record is checked by writing it, and the format Finalize, when
+// defined, computes the trailers and totals of the document.
+//
+// Parameters:
+//   - format: The format of the document.
+//   - reader: The JSON Lines. Blank lines are accepted.
+//   - options: The naming of the field keys.
+//
+// Returns:
+//   - Document: The records, whose String method writes the file.
+//   - error: ErrUnregisteredFormat, ErrUnknownRecordKind, ErrUnknownField, a validation error or an
+//     error of the format Finalize, with the line number of the record when it has one.
+//
+// Example:
+//
+//	document, err := ImportJSONLines(FormatBradesco80, input, JSONLinesOptions{Naming: FieldNamingGo})
+//	if err != nil {
+//	    // Handle the error
+//	}
+//	file, err := document.String()
+func ImportJSONLines(format Format, reader io.Reader, options JSONLinesOptions) (Document, error) {
+	definition, ok := Lookup(format)
+
+	if !ok {
+		return Document{}, wraperrors.NewErrWrap(ErrUnregisteredFormat, fmt.Errorf("format %q", format))
+	}
+
+	document := Document{Format: format}
+
+	err := scanLines(reader, func(_ int, line string) error {
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+
+		record, err := importRecord(definition, []byte(line), options.Naming)
+
+		if err != nil {
+			return err
+		}
+
+		document.Records = append(document.Records, record)
+
+		return nil
+	})
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	if definition.Finalize == nil {
+		return document, nil
+	}
+
+	records, err := definition.Finalize(document.Records)
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	document.Records = records
+
+	return document, nil
+}
+
+// importRecord builds the record of a JSON line, filling its defaults and checking it is written.
+func importRecord(definition FormatDefinition, line []byte, naming FieldNaming) (Record, error) {
+	var imported importedLine
+
+	if err := json.Unmarshal(line, &imported); err != nil {
+		return nil, err
+	}
+
+	zero, ok := definition.Records[imported.RecordType]
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRecordKind, imported.RecordType)
+	}
+
+	value := reflect.New(reflect.TypeOf(zero))
+
+	if err := importStruct(value.Elem(), imported.Fields, naming); err != nil {
+		return nil, err
+	}
+
+	if err := FillDefaults(value.Interface()); err != nil {
+		return nil, err
+	}
+
+	record := value.Elem().Interface().(Record)
+
+	if _, err := record.String(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// importStruct assigns the fields of a record tagged with `translator` from a JSON object.
+func importStruct(structValue reflect.Value, fields map[string]json.RawMessage, naming FieldNaming) error {
+	assigned, err := importFields(structValue, fields, naming)
+
+	if err != nil || assigned == len(fields) {
+		return err
+	}
+
+	structType := structValue.Type()
+
+	for key := range fields {
+		if !hasFieldKey(structType, key, naming) {
+			return fmt.Errorf("%w: %q of %s", ErrUnknownField, key, structType.Name())
+		}
+	}
+
+	return nil
+}
+
+// importFields assigns the fields of a struct found in a JSON object, including the fields of
+// its embedded blocks, and returns how many keys it assigned.
+func importFields(structValue reflect.Value, fields map[string]json.RawMessage, naming FieldNaming) (int, error) {
+	structType := structValue.Type()
+	assigned := 0
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		if embeddedBlock(field, tag) {
+			count, err := importFields(structValue.Field(i), fields, naming)
+
+			if err != nil {
+				return 0, err
+			}
+
+			assigned += count
+			continue
+		}
+
+		raw, ok := fields[fieldKey(field, naming)]
+
+		if !ok {
+			continue
+		}
+
+		assigned++
+
+		if err := importValue(structValue.Field(i), raw, naming); err != nil {
+			return 0, fmt.Errorf("at field %s: %w", field.Name, err)
+		}
+	}
+
+	return assigned, nil
+}
+
+// importValue assigns a field from its JSON value, as ExportJSONLines writes it.
+func importValue(value reflect.Value, raw json.RawMessage, naming FieldNaming) error {
+	if string(raw) == "null" {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	switch value.Interface().(type) {
+	case time.Time:
+		var text string
+
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return err
+		}
+
+		moment, err := time.Parse(time.RFC3339, text)
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(moment))
+
+		return nil
+	case decimal.Decimal:
+		return json.Unmarshal(raw, value.Addr().Interface())
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		element := reflect.New(value.Type().Elem())
+
+		if err := importValue(element.Elem(), raw, naming); err != nil {
+			return err
+		}
+
+		value.Set(element)
+
+		return nil
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+
+		return importStruct(value, fields, naming)
+	case reflect.Array, reflect.Slice:
+		var elements []json.RawMessage
+
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return err
+		}
+
+		if value.Kind() == reflect.Slice {
+			value.Set(reflect.MakeSlice(value.Type(), len(elements), len(elements)))
+		} else if len(elements) > value.Len() {
+			return fmt.Errorf("%w: %d elements for %d occurrences", ErrTooManyOccurrences, len(elements), value.Len())
+		}
+
+		for i, element := range elements {
+			if err := importValue(value.Index(i), element, naming); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return json.Unmarshal(raw, value.Addr().Interface())
+}
+
+// hasFieldKey reports whether a struct, or one of its embedded blocks, has a field tagged with
+// `translator` under key.
+func hasFieldKey(structType reflect.Type, key string, naming FieldNaming) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		if embeddedBlock(field, tag) {
+			if hasFieldKey(field.Type, key, naming) {
+				return true
+			}
+
+			continue
+		}
+
+		if fieldKey(field, naming) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldKey returns the key of a field in the naming requested.
+func fieldKey(field reflect.StructField, naming FieldNaming) string {
+	switch naming {
+	case FieldNamingSnakeCase:
+		return snakeCase(field.Name)
+	case FieldNamingSpec:
+		if spec := field.Tag.Get("spec"); spec != "" {
+			return spec
+		}
+
+		return snakeCase(field.Name)
+	default:
+		return field.Name
+	}
+}
+
+// tagPrecision returns the precision option of a translator tag, or -1 when there is none.
+func tagPrecision(tag string) int {
+	if precision, err := strconv.Atoi(tagOptions(tag)["precision"]); err == nil {
+		return precision
+	}
+
+	return -1
+}
+
+// snakeCase converts a Go name to snake_case, keeping acronyms and numbers together,
+// e.g. "BRCode" to "br_code" and "DuePriceNext15To30Days" to "due_price_next_15_to_30_days".
+func snakeCase(name string) string {
+	var builder strings.Builder
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			switch {
+			case unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)),
+				unicode.IsUpper(r) && unicode.IsUpper(previous) && nextIsLower,
+				unicode.IsDigit(r) && unicode.IsLetter(previous):
+				builder.WriteByte('_')
+			}
+		}
+
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,208 @@
+package documenttranslator_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	"github.com/libercapital/document-translator-go/bradesco226"
+	"github.com/libercapital/document-translator-go/getnetextrato"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ratingLines = []string{
+	"000052998224725000000000150025",
+	"026404731000196000000001000000",
+}
+
+func TestExportJSONLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		naming   documenttranslator.FieldNaming
+		expected string
+	}{
+		{
+			name:   "go names",
+			naming: documenttranslator.FieldNamingGo,
+			expected: `{"record_type":"rating","line_number":1,"fields":{"DocumentNumber":"000052998224725","Amount":"1500.25"}}` + "\n" +
+				`{"record_type":"rating","line_number":3,"fields":{"DocumentNumber":"026404731000196","Amount":"10000.00"}}` + "\n",
+		},
+		{
+			name:   "snake case",
+			naming: documenttranslator.FieldNamingSnakeCase,
+			expected: `{"record_type":"rating","line_number":1,"fields":{"document_number":"000052998224725","amount":"1500.25"}}` + "\n" +
+				`{"record_type":"rating","line_number":3,"fields":{"document_number":"026404731000196","amount":"10000.00"}}` + "\n",
+		},
+		{
+			name:   "spec names",
+			naming: documenttranslator.FieldNamingSpec,
+			expected: `{"record_type":"rating","line_number":1,"fields":{"cpf_cnpj":"000052998224725","valor":"1500.25"}}` + "\n" +
+				`{"record_type":"rating","line_number":3,"fields":{"cpf_cnpj":"026404731000196","valor":"10000.00"}}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			file := ratingLines[0] + "\r\n\r\n" + ratingLines[1] + "\r\n"
+			err := documenttranslator.ExportJSONLines(documenttranslator.FormatBradescoRating, strings.NewReader(file), &output, documenttranslator.JSONLinesOptions{Naming: test.naming})
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestExportJSONLinesValues(t *testing.T) {
+	var output bytes.Buffer
+
+	err := documenttranslator.ExportJSONLines(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(brf240Lines, "\n")), &output, documenttranslator.JSONLinesOptions{Naming: documenttranslator.FieldNamingSnakeCase})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Len(t, lines, len(brf240Lines))
+
+	var header struct {
+		RecordType string                 `json:"record_type"`
+		LineNumber int                    `json:"line_number"`
+		Fields     map[string]interface{} `json:"fields"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, "0", header.RecordType)
+	assert.Equal(t, 1, header.LineNumber)
+	assert.Equal(t, "72493216000147", header.Fields["buyer_document"])
+	assert.Equal(t, "2019-06-03T00:00:00Z", header.Fields["file_date"])
+	assert.Equal(t, "2019-06-03T21:31:00Z", header.Fields["file_date_time"])
+	assert.Equal(t, float64(5892), header.Fields["sequential_number"])
+
+	var segment struct {
+		RecordType string                 `json:"record_type"`
+		Fields     map[string]interface{} `json:"fields"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[2]), &segment))
+	assert.Equal(t, "3A", segment.RecordType)
+	assert.Equal(t, "9523.57", segment.Fields["payment_value"])
+}
+
+func TestExportJSONLinesErrors(t *testing.T) {
+	var output bytes.Buffer
+
+	err := documenttranslator.ExportJSONLines(documenttranslator.Format("cnab400"), strings.NewReader(ratingLines[0]), &output, documenttranslator.JSONLinesOptions{})
+	assert.ErrorIs(t, err, documenttranslator.ErrUnregisteredFormat)
+
+	err = documenttranslator.ExportJSONLines(documenttranslator.FormatBradescoRating, strings.NewReader(ratingLines[0]+"\n000052998224726000000000150025"), &output, documenttranslator.JSONLinesOptions{})
+	assert.ErrorIs(t, err, documenttranslator.ErrValidation)
+	assert.Contains(t, err.Error(), "at line 2")
+}
+
+func TestImportJSONLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"record_type":"0","fields":{"DataMovimento":"2024-01-16T00:00:00Z","Nome":"Banco Bradesco S.A.","EmpresaOrigem":1234}}`,
+		``,
+		`{"record_type":"1","fields":{"SistemaOrigem":"BDN","CodigoConvenio":"000012345","ContratoOrigem":"000098765","TipoPagamento":"L","DataVencimentoParcela":"2024-02-15T00:00:00Z","Produto":"010","Familia":"1","Contrato":"000054321","ValorPagameto":"1500.25","NumeroParcela":"003","ADebitarNaConta":"S","IdentificadorRecompraLiquidacao":"L"}}`,
+	}, "\n")
+
+	document, err := documenttranslator.ImportJSONLines(documenttranslator.FormatBradesco80, strings.NewReader(input), documenttranslator.JSONLinesOptions{})
+	assert.NoError(t, err)
+
+	file, err := document.String()
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"016012024Banco Bradesco S.A.                     0001234                        ",
+		"1BDN000012345000098765L15022024010100005432100000000000150025003S              L",
+		"9000003                                                                         ",
+	}, "\r\n")+"\r\n", file)
+}
+
+func TestImportJSONLinesGetnetTrailer(t *testing.T) {
+	header := "01609202407132916092024CEADM1001013903        10440482000154GETNET S.A.         000002516GSSANT. V.10.1 400 BYTES" + strings.Repeat(" ", 287)
+	trailer := "9000000047" + strings.Repeat(" ", 390)
+
+	for _, lines := range [][]string{{header}, {header, trailer}} {
+		var exported bytes.Buffer
+
+		err := documenttranslator.ExportJSONLines(documenttranslator.FormatGetnetExtrato, strings.NewReader(strings.Join(lines, "\r\n")), &exported, documenttranslator.JSONLinesOptions{})
+		assert.NoError(t, err)
+
+		document, err := documenttranslator.ImportJSONLines(documenttranslator.FormatGetnetExtrato, &exported, documenttranslator.JSONLinesOptions{})
+		assert.NoError(t, err)
+
+		written, err := document.Lines()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{header, "9000000002" + strings.Repeat(" ", 390)}, written)
+	}
+
+	_, err := documenttranslator.ImportJSONLines(documenttranslator.FormatGetnetExtrato, strings.NewReader(`{"record_type":"9","fields":{}}`), documenttranslator.JSONLinesOptions{})
+	assert.ErrorIs(t, err, getnetextrato.ErrMisplacedRegister)
+}
+
+func TestImportExportedJSONLines(t *testing.T) {
+	for _, naming := range []documenttranslator.FieldNaming{documenttranslator.FieldNamingGo, documenttranslator.FieldNamingSnakeCase, documenttranslator.FieldNamingSpec} {
+		var exported bytes.Buffer
+
+		options := documenttranslator.JSONLinesOptions{Naming: naming}
+		err := documenttranslator.ExportJSONLines(documenttranslator.FormatBradescoRating, strings.NewReader(strings.Join(ratingLines, "\n")), &exported, options)
+		assert.NoError(t, err)
+
+		document, err := documenttranslator.ImportJSONLines(documenttranslator.FormatBradescoRating, &exported, options)
+		assert.NoError(t, err)
+
+		lines, err := document.Lines()
+		assert.NoError(t, err)
+		assert.Equal(t, ratingLines, lines)
+	}
+}
+
+func TestImportJSONLinesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  documenttranslator.Format
+		input   string
+		wantErr error
+	}{
+		{
+			name:    "unregistered format",
+			format:  documenttranslator.Format("cnab400"),
+			input:   `{"record_type":"rating","fields":{}}`,
+			wantErr: documenttranslator.ErrUnregisteredFormat,
+		},
+		{
+			name:    "unknown record type",
+			format:  documenttranslator.FormatBradescoRating,
+			input:   `{"record_type":"trailer","fields":{}}`,
+			wantErr: documenttranslator.ErrUnknownRecordKind,
+		},
+		{
+			name:    "unknown field",
+			format:  documenttranslator.FormatBradescoRating,
+			input:   `{"record_type":"rating","fields":{"DocumentNumber":"52998224725","Valor":"1.00"}}`,
+			wantErr: documenttranslator.ErrUnknownField,
+		},
+		{
+			name:    "invalid document",
+			format:  documenttranslator.FormatBradescoRating,
+			input:   `{"record_type":"rating","fields":{"DocumentNumber":"52998224726","Amount":"1.00"}}`,
+			wantErr: documenttranslator.ErrValidation,
+		},
+		{
+			name:    "header quantities without header",
+			format:  documenttranslator.FormatBradesco226,
+			input:   `{"record_type":"3","fields":{"ContractNumber":"000054321"}}`,
+			wantErr: bradesco226.ErrMisplacedHeader,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := documenttranslator.ImportJSONLines(test.format, strings.NewReader(test.input), documenttranslator.JSONLinesOptions{})
+
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
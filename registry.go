@@ -0,0 +1,239 @@
+package documenttranslator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+var (
+	ErrUnregisteredFormat = errors.New("format is not registered")
+	ErrUnknownRecordKind  = errors.New("line kind does not match any record of the format")
+)
+
+// Record is a record of any format, able to write itself back as a line.
+type Record interface {
+	String() (string, error)
+}
+
+// FormatDefinition describes how a format reads its lines. Format packages register their
+// definition when imported, e.g. with a blank import of brf240.
+type FormatDefinition struct {
+	Name         Format                            // Name identifies the format.
+	RecordLength int                               // RecordLength is the length of every line of the format.
+	Kind         func(line string) (string, error) // Kind returns the key of the record type of a line in Records.
+	Records      map[string]Record                 // Records holds a zero value of each record type by its kind.
+	Parse        func(line string) (Record, error) // Parse reads a line as the record type of its kind.
+
+	// NewParser, when defined, returns the function reading the lines of a single document in
+	// place of Parse, for formats whose lines depend on an earlier line, e.g. the layout version
+	// declared by the header.
+	NewParser func() func(line string) (Record, error)
+
+	// Finalize, when defined, completes the records of a document built by ImportJSONLines,
+	// e.g. adding or recomputing its trailers.
+	Finalize func(records []Record) ([]Record, error)
+}
+
+// parser returns the function reading the lines of a document of the format.
+func (d FormatDefinition) parser() func(line string) (Record, error) {
+	if d.NewParser != nil {
+		return d.NewParser()
+	}
+
+	return d.Parse
+}
+
+// Document is a file of any registered format, as the records of its lines.
+type Document struct {
+	Format  Format
+	Records []Record
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = map[Format]FormatDefinition{}
+)
+
+// Register makes a format available to ParseDocument and ReadDocument. It panics when the
+// definition is incomplete or its format is already registered.
+func Register(definition FormatDefinition) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if definition.Name == FormatUnknown || definition.Kind == nil || definition.Parse == nil {
+		panic(fmt.Sprintf("documenttranslator: incomplete definition of format %q", definition.Name))
+	}
+
+	if _, ok := registry[definition.Name]; ok {
+		panic(fmt.Sprintf("documenttranslator: format %q registered twice", definition.Name))
+	}
+
+	registry[definition.Name] = definition
+}
+
+// Lookup returns the definition of a registered format.
+func Lookup(format Format) (FormatDefinition, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	definition, ok := registry[format]
+
+	return definition, ok
+}
+
+// Formats returns the registered formats, sorted by name.
+func Formats() []Format {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	var formats []Format
+
+	for format := range registry {
+		formats = append(formats, format)
+	}
+
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+
+	return formats
+}
+
+// ParseDocument reads every line of a file of a registered format.
+//
+// Parameters:
+//   - format: The format of the file.
+//   - reader: The file contents. Blank lines and CRLF line endings are accepted.
+//
+// Returns:
+//   - Document: The records of the file, in line order.
+//   - error: ErrUnregisteredFormat for unknown formats, or the error of the first line not parsed, with its line number.
+//
+// Example:
+//
+//	document, err := ParseDocument(FormatBRF240, file)
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ParseDocument(format Format, reader io.Reader) (Document, error) {
+	definition, ok := Lookup(format)
+
+	if !ok {
+		return Document{}, wraperrors.NewErrWrap(ErrUnregisteredFormat, fmt.Errorf("format %q", format))
+	}
+
+	document := Document{Format: format}
+
+	err := definition.eachRecord(reader, func(_ int, _ string, record Record) error {
+		document.Records = append(document.Records, record)
+		return nil
+	})
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	return document, nil
+}
+
+// eachRecord parses every line of a file of the format and calls yield with the number, kind
+// and record of each line, stopping at the first error.
+func (d FormatDefinition) eachRecord(reader io.Reader, yield func(lineNumber int, kind string, record Record) error) error {
+	parse := d.parser()
+
+	return scanLines(reader, func(lineNumber int, line string) error {
+		kind, err := d.Kind(line)
+
+		if err != nil {
+			return err
+		}
+
+		record, err := parse(line)
+
+		if err != nil {
+			return err
+		}
+
+		return yield(lineNumber, kind, record)
+	})
+}
+
+// scanLines calls yield with every non-blank line of a file and its number, counting from 1,
+// without the carriage return of CRLF line endings. An error of yield stops the scan and is
+// returned with the line it happened at.
+func scanLines(reader io.Reader, yield func(lineNumber int, line string) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, bufio.MaxScanTokenSize*16)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if line == "" {
+			continue
+		}
+
+		if err := yield(lineNumber, line); err != nil {
+			return wraperrors.NewErrWrap(err, fmt.Errorf("at line %d", lineNumber))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// ReadDocument detects the format of a file with Detect and reads it with ParseDocument.
+// The detected format must be registered.
+func ReadDocument(reader io.Reader) (Document, error) {
+	contents, err := io.ReadAll(reader)
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	detection, err := Detect(bytes.NewReader(contents))
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	return ParseDocument(detection.Format, bytes.NewReader(contents))
+}
+
+// String writes the document with lines ended by CRLF.
+func (d Document) String() (string, error) {
+	lines, err := d.Lines()
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n", nil
+}
+
+// Lines writes every record of the document, in order.
+func (d Document) Lines() ([]string, error) {
+	lines := make([]string, 0, len(d.Records))
+
+	for i, record := range d.Records {
+		line, err := record.String()
+
+		if err != nil {
+			return nil, wraperrors.NewErrWrap(err, fmt.Errorf("at record %d", i+1))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,84 @@
+package documenttranslator_test
+
+import (
+	"strings"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+	_ "github.com/libercapital/document-translator-go/bradesco226"
+	_ "github.com/libercapital/document-translator-go/bradesco600"
+	_ "github.com/libercapital/document-translator-go/bradesco80"
+	_ "github.com/libercapital/document-translator-go/bradescorating"
+	"github.com/libercapital/document-translator-go/brf240"
+	_ "github.com/libercapital/document-translator-go/getnetextrato"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var brf240Lines = []string{
+	"35300000         272493216000147003320500085000000650189370000005361516 BRF S/A                       BANCO SANTANDER                         20306201921310000589206006250                                                                     ",
+	"35300011C2003060 272493216000147003320500085000000650189370000005361516 BRF S/A                       TITULO DISPONIVEL PARA NEGOCIACAO       0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000          ",
+	"3530001300001A000FORNECEDOR 1                        2           3648853400015600033000003808      130023471       000014000-1-00103062019030620190000000000000009523570000000000000000000000000000000000000033PS250051005512682019001          ",
+	"35300015         035193000000040420170731000000000000000000                                                                                                                                                                                     ",
+	"35399999         000001035195                                                                                                                                                                                                                   ",
+}
+
+func TestFormats(t *testing.T) {
+	assert.Equal(t, []documenttranslator.Format{
+		documenttranslator.FormatBradesco226,
+		documenttranslator.FormatBradesco600,
+		documenttranslator.FormatBradesco80,
+		documenttranslator.FormatBradescoRating,
+		documenttranslator.FormatBRF240,
+		documenttranslator.FormatBRF240Return,
+		documenttranslator.FormatGetnetExtrato,
+	}, documenttranslator.Formats())
+}
+
+func TestLookupKind(t *testing.T) {
+	definition, ok := documenttranslator.Lookup(documenttranslator.FormatBRF240)
+	assert.True(t, ok)
+	assert.Equal(t, 240, definition.RecordLength)
+
+	for _, line := range brf240Lines {
+		kind, err := definition.Kind(line)
+		assert.NoError(t, err)
+
+		record, err := definition.Parse(line)
+		assert.NoError(t, err)
+		assert.IsType(t, definition.Records[kind], record)
+	}
+
+	_, ok = documenttranslator.Lookup("cnab400")
+	assert.False(t, ok)
+}
+
+func TestReadDocument(t *testing.T) {
+	document, err := documenttranslator.ReadDocument(strings.NewReader(strings.Join(brf240Lines, "\r\n") + "\r\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, documenttranslator.FormatBRF240, document.Format)
+	assert.Len(t, document.Records, 5)
+	assert.IsType(t, brf240.BillingSegmentA{}, document.Records[2])
+
+	lines, err := document.Lines()
+
+	assert.NoError(t, err)
+	assert.Equal(t, brf240Lines, lines)
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	_, err := documenttranslator.ParseDocument("cnab400", strings.NewReader(brf240Lines[0]))
+	assert.ErrorIs(t, err, documenttranslator.ErrUnregisteredFormat)
+
+	_, err = documenttranslator.ParseDocument(documenttranslator.FormatBRF240, strings.NewReader(brf240Lines[0]+"\n"+brf240Lines[1][:100]))
+	assert.ErrorIs(t, err, documenttranslator.ErrParseShorterThenDeliminator)
+	assert.ErrorContains(t, err, "at line 2")
+}
+
+func TestRegisterTwice(t *testing.T) {
+	definition, _ := documenttranslator.Lookup(documenttranslator.FormatBRF240)
+
+	assert.Panics(t, func() { documenttranslator.Register(definition) })
+	assert.Panics(t, func() { documenttranslator.Register(documenttranslator.FormatDefinition{Name: "incomplete"}) })
+}
@@ -0,0 +1,571 @@
+package documenttranslator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/libercapital/document-translator-go/internal/wraperrors"
+)
+
+// DecimalStyle selects how ExportCSV writes decimals.
+type DecimalStyle int
+
+const (
+	DecimalStyleEnUS DecimalStyle = iota // DecimalStyleEnUS writes decimals with a point, e.g. "1234.56".
+	DecimalStylePtBR                     // DecimalStylePtBR writes decimals with a comma, e.g. "1234,56".
+)
+
+// defaultDateLayout is the layout of the dates exported to spreadsheets when none is configured.
+const defaultDateLayout = "2006-01-02"
+
+// SpreadsheetOptions configures ExportCSV and ExportXLSX.
+type SpreadsheetOptions struct {
+	Naming       FieldNaming  // Naming selects the column headers, from the Go field names or their spec tags.
+	DecimalStyle DecimalStyle // DecimalStyle is the decimal separator of CSV files. XLSX sheets hold numbers, shown in the reader locale.
+	DateLayout   string       // DateLayout is the time layout of dates, "2006-01-02" when empty.
+	Comma        rune         // Comma is the CSV field separator, ',' for DecimalStyleEnUS and ';' for DecimalStylePtBR when zero.
+}
+
+// spreadsheetCell is a column of a record, named after its field path.
+type spreadsheetCell struct {
+	header    string
+	value     interface{}
+	precision int
+}
+
+// spreadsheetSheet holds the records of one record type.
+type spreadsheetSheet struct {
+	name    string
+	headers []string
+	columns map[string]int // columns holds the index of each header.
+	rows    [][]spreadsheetCell
+}
+
+// ExportCSV reads every line of a file of a registered format and writes the records of each
+// record type as a CSV file, whose first row holds the headers. Every row starts with the line
+// number of its record. Nested structs are flattened in columns named after their field path,
+// e.g. "Return.Code", and the elements of repeating groups are joined by "|".
+//
+// Parameters:
+//   - format: The format of the file.
+//   - reader: The file contents. Blank lines and CRLF line endings are accepted.
+//   - options: The column headers and the formatting of decimals and dates.
+//
+// Returns:
+//   - map[string][]byte: The CSV file of each record type, by its kind in the format Records.
+//   - error: ErrUnregisteredFormat for unknown formats, or the error of the first line not parsed, with its line number.
+//
+// Example:
+//
+//	files, err := ExportCSV(FormatGetnetExtrato, file, SpreadsheetOptions{DecimalStyle: DecimalStylePtBR, DateLayout: "02/01/2006"})
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ExportCSV(format Format, reader io.Reader, options SpreadsheetOptions) (map[string][]byte, error) {
+	sheets, err := readSheets(format, reader, options.Naming)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+
+	for _, sheet := range sheets {
+		var file bytes.Buffer
+
+		writer := csv.NewWriter(&file)
+		writer.Comma = options.comma()
+
+		if err := writer.Write(sheet.headers); err != nil {
+			return nil, err
+		}
+
+		for _, row := range sheet.rows {
+			record := make([]string, len(row))
+
+			for i, cell := range row {
+				record[i] = options.formatText(cell)
+			}
+
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+
+		writer.Flush()
+
+		if err := writer.Error(); err != nil {
+			return nil, err
+		}
+
+		files[sheet.name] = file.Bytes()
+	}
+
+	return files, nil
+}
+
+// ExportXLSX reads every line of a file of a registered format and writes a workbook with one
+// sheet per record type, named after its kind in the format Records and laid out as the files
+// of ExportCSV. Numbers and decimals are written as numeric cells, dates as text in the
+// DateLayout and the remaining fields as text, keeping their leading zeros.
+//
+// Parameters:
+//   - format: The format of the file.
+//   - reader: The file contents. Blank lines and CRLF line endings are accepted.
+//   - writer: Receives the workbook.
+//   - options: The column headers and the formatting of dates.
+//
+// Returns:
+//   - error: ErrUnregisteredFormat for unknown formats, or the error of the first line not parsed, with its line number.
+//
+// Example:
+//
+//	err := ExportXLSX(FormatBRF240Return, file, workbook, SpreadsheetOptions{Naming: FieldNamingSpec})
+//	if err != nil {
+//	    // Handle the error
+//	}
+func ExportXLSX(format Format, reader io.Reader, writer io.Writer, options SpreadsheetOptions) error {
+	sheets, err := readSheets(format, reader, options.Naming)
+
+	if err != nil {
+		return err
+	}
+
+	workbook := zip.NewWriter(writer)
+
+	var overrides, entries, relationships strings.Builder
+
+	for i, sheet := range sheets {
+		number := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, number)
+		fmt.Fprintf(&entries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlText(sheet.name), number, number)
+		fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, number, number)
+
+		if err := writeZipEntry(workbook, fmt.Sprintf("xl/worksheets/sheet%d.xml", number), options.worksheet(sheet)); err != nil {
+			return err
+		}
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "[Content_Types].xml",
+			content: `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+				`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+				`<Default Extension="xml" ContentType="application/xml"/>` +
+				`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+				overrides.String() + `</Types>`,
+		},
+		{
+			name: "_rels/.rels",
+			content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+				`</Relationships>`,
+		},
+		{
+			name: "xl/workbook.xml",
+			content: `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+				`<sheets>` + entries.String() + `</sheets></workbook>`,
+		},
+		{
+			name:    "xl/_rels/workbook.xml.rels",
+			content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + relationships.String() + `</Relationships>`,
+		},
+	}
+
+	for _, part := range parts {
+		if err := writeZipEntry(workbook, part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	return workbook.Close()
+}
+
+// readSheets parses every line of a file and groups its records by record type, in the order
+// each type first appears.
+func readSheets(format Format, reader io.Reader, naming FieldNaming) ([]spreadsheetSheet, error) {
+	definition, ok := Lookup(format)
+
+	if !ok {
+		return nil, wraperrors.NewErrWrap(ErrUnregisteredFormat, fmt.Errorf("format %q", format))
+	}
+
+	var sheets []spreadsheetSheet
+
+	indexes := map[string]int{}
+
+	err := definition.eachRecord(reader, func(lineNumber int, kind string, record Record) error {
+		lineHeader := "LineNumber"
+
+		if naming != FieldNamingGo {
+			lineHeader = "line_number"
+		}
+
+		index, ok := indexes[kind]
+
+		if !ok {
+			index = len(sheets)
+			indexes[kind] = index
+			sheets = append(sheets, newSpreadsheetSheet(kind, append([]string{lineHeader}, recordHeaders(reflect.TypeOf(record), "", naming)...)))
+		}
+
+		cells := append([]spreadsheetCell{{header: lineHeader, value: int64(lineNumber)}}, flattenRecord(reflect.ValueOf(record), "", naming)...)
+		sheets[index].rows = append(sheets[index].rows, sheets[index].row(cells))
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sheets, nil
+}
+
+// newSpreadsheetSheet returns an empty sheet with the given headers.
+func newSpreadsheetSheet(name string, headers []string) spreadsheetSheet {
+	sheet := spreadsheetSheet{name: name, headers: headers, columns: make(map[string]int, len(headers))}
+
+	for i, header := range headers {
+		sheet.columns[header] = i
+	}
+
+	return sheet
+}
+
+// row places each cell under the column of its header, leaving empty the columns of values
+// the record does not hold, e.g. nil pointers.
+func (s spreadsheetSheet) row(cells []spreadsheetCell) []spreadsheetCell {
+	row := make([]spreadsheetCell, len(s.headers))
+
+	for i, header := range s.headers {
+		row[i] = spreadsheetCell{header: header}
+	}
+
+	for _, cell := range cells {
+		if column, ok := s.columns[cell.header]; ok {
+			row[column] = cell
+		}
+	}
+
+	return row
+}
+
+// recordHeaders returns the headers of the cells flattenRecord returns for a record type,
+// whatever values its records hold.
+func recordHeaders(structType reflect.Type, prefix string, naming FieldNaming) (headers []string) {
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		header := prefix + fieldKey(field, naming)
+
+		if embeddedBlock(field, tag) {
+			headers = append(headers, recordHeaders(field.Type, prefix, naming)...)
+			continue
+		}
+
+		if _, isPart := tagOptions(tag)["part"]; !isPart && field.Type.Kind() == reflect.Struct {
+			headers = append(headers, recordHeaders(field.Type, header+".", naming)...)
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Array {
+			headers = append(headers, arrayHeaders(field.Type, header, naming)...)
+			continue
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers
+}
+
+// arrayHeaders returns the headers of the cells flattenArray returns for an array type.
+func arrayHeaders(arrayType reflect.Type, header string, naming FieldNaming) (headers []string) {
+	element := arrayType.Elem()
+	nested := element.Kind() == reflect.Struct && element != reflect.TypeOf(time.Time{}) && element != reflect.TypeOf(decimal.Decimal{})
+
+	for i := 0; i < arrayType.Len(); i++ {
+		elementHeader := header + "." + strconv.Itoa(i+1)
+
+		if nested {
+			headers = append(headers, recordHeaders(element, elementHeader+".", naming)...)
+			continue
+		}
+
+		headers = append(headers, elementHeader)
+	}
+
+	return headers
+}
+
+// flattenRecord returns a cell for each field of a record tagged with `translator`,
+// descending into nested structs and embedded blocks and giving each element of an array its
+// own cells.
+func flattenRecord(structValue reflect.Value, prefix string, naming FieldNaming) (cells []spreadsheetCell) {
+	for structValue.Kind() == reflect.Pointer || structValue.Kind() == reflect.Interface {
+		if structValue.IsNil() {
+			return nil
+		}
+
+		structValue = structValue.Elem()
+	}
+
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := layoutTag(field)
+
+		if !ok {
+			continue
+		}
+
+		header := prefix + fieldKey(field, naming)
+		fieldValue := structValue.Field(i)
+
+		if embeddedBlock(field, tag) {
+			cells = append(cells, flattenRecord(fieldValue, prefix, naming)...)
+			continue
+		}
+
+		if _, isPart := tagOptions(tag)["part"]; !isPart && fieldValue.Kind() == reflect.Struct {
+			cells = append(cells, flattenRecord(fieldValue, header+".", naming)...)
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Array {
+			cells = append(cells, flattenArray(fieldValue, header, tagPrecision(tag), naming)...)
+			continue
+		}
+
+		cells = append(cells, spreadsheetCell{header: header, value: cellValue(fieldValue), precision: tagPrecision(tag)})
+	}
+
+	return cells
+}
+
+// flattenArray returns the cells of each element of an occurs array, headed by the field key
+// and the element position, e.g. "due_price_buckets.1".
+func flattenArray(array reflect.Value, header string, precision int, naming FieldNaming) (cells []spreadsheetCell) {
+	for i := 0; i < array.Len(); i++ {
+		elementHeader := header + "." + strconv.Itoa(i+1)
+		element := array.Index(i)
+
+		switch element.Interface().(type) {
+		case time.Time, decimal.Decimal:
+		default:
+			if element.Kind() == reflect.Struct {
+				cells = append(cells, flattenRecord(element, elementHeader+".", naming)...)
+				continue
+			}
+		}
+
+		cells = append(cells, spreadsheetCell{header: elementHeader, value: cellValue(element), precision: precision})
+	}
+
+	return cells
+}
+
+// cellValue converts a field to a string, int64, bool, decimal.Decimal, time.Time, []interface{} or nil.
+func cellValue(value reflect.Value) interface{} {
+	switch field := value.Interface().(type) {
+	case time.Time, decimal.Decimal:
+		return field
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+
+		return cellValue(value.Elem())
+	case reflect.Array, reflect.Slice:
+		values := make([]interface{}, value.Len())
+
+		for i := range values {
+			values[i] = cellValue(value.Index(i))
+		}
+
+		return values
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint())
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.String:
+		return value.String()
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+// formatText writes a cell as text, with the decimal separator and date layout of the options.
+func (o SpreadsheetOptions) formatText(cell spreadsheetCell) string {
+	switch value := cell.value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+
+		return value.Format(o.dateLayout())
+	case decimal.Decimal:
+		text := value.String()
+
+		if cell.precision >= 0 {
+			text = value.StringFixed(int32(cell.precision))
+		}
+
+		if o.DecimalStyle == DecimalStylePtBR {
+			text = strings.Replace(text, ".", ",", 1)
+		}
+
+		return text
+	case []interface{}:
+		texts := make([]string, len(value))
+
+		for i, element := range value {
+			texts[i] = o.formatText(spreadsheetCell{value: element, precision: cell.precision})
+		}
+
+		return strings.Join(texts, "|")
+	}
+
+	return fmt.Sprint(cell.value)
+}
+
+// worksheet writes the XML of a sheet, with numeric cells for numbers and inline strings for the rest.
+func (o SpreadsheetOptions) worksheet(sheet spreadsheetSheet) string {
+	var xmlSheet strings.Builder
+
+	xmlSheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	writeRow := func(rowNumber int, cells []spreadsheetCell, header bool) {
+		fmt.Fprintf(&xmlSheet, `<row r="%d">`, rowNumber)
+
+		for i, cell := range cells {
+			reference := columnName(i) + strconv.Itoa(rowNumber)
+
+			if header {
+				fmt.Fprintf(&xmlSheet, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, reference, xmlText(cell.header))
+				continue
+			}
+
+			switch value := cell.value.(type) {
+			case nil:
+				continue
+			case int64:
+				fmt.Fprintf(&xmlSheet, `<c r="%s"><v>%d</v></c>`, reference, value)
+			case decimal.Decimal:
+				fmt.Fprintf(&xmlSheet, `<c r="%s"><v>%s</v></c>`, reference, value.String())
+			case bool:
+				fmt.Fprintf(&xmlSheet, `<c r="%s" t="b"><v>%d</v></c>`, reference, map[bool]int{false: 0, true: 1}[value])
+			default:
+				text := o.formatText(cell)
+
+				if text != "" {
+					fmt.Fprintf(&xmlSheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, xmlText(text))
+				}
+			}
+		}
+
+		xmlSheet.WriteString(`</row>`)
+	}
+
+	headers := make([]spreadsheetCell, len(sheet.headers))
+
+	for i, header := range sheet.headers {
+		headers[i] = spreadsheetCell{header: header}
+	}
+
+	writeRow(1, headers, true)
+
+	for i, row := range sheet.rows {
+		writeRow(i+2, row, false)
+	}
+
+	xmlSheet.WriteString(`</sheetData></worksheet>`)
+
+	return xmlSheet.String()
+}
+
+func (o SpreadsheetOptions) comma() rune {
+	if o.Comma != 0 {
+		return o.Comma
+	}
+
+	if o.DecimalStyle == DecimalStylePtBR {
+		return ';'
+	}
+
+	return ','
+}
+
+func (o SpreadsheetOptions) dateLayout() string {
+	if o.DateLayout == "" {
+		return defaultDateLayout
+	}
+
+	return o.DateLayout
+}
+
+// columnName returns the letters of a zero based column index, e.g. "A", "Z" or "AA".
+func columnName(index int) string {
+	name := ""
+
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+
+	return name
+}
+
+func xmlText(text string) string {
+	var escaped strings.Builder
+
+	_ = xml.EscapeText(&escaped, []byte(text))
+
+	return escaped.String()
+}
+
+func writeZipEntry(archive *zip.Writer, name string, content string) error {
+	entry, err := archive.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(entry, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"+content)
+
+	return err
+}
@@ -0,0 +1,151 @@
+package documenttranslator_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	documenttranslator "github.com/libercapital/document-translator-go"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  documenttranslator.SpreadsheetOptions
+		expected string
+	}{
+		{
+			name:    "en-US",
+			options: documenttranslator.SpreadsheetOptions{},
+			expected: "LineNumber,DocumentNumber,Amount\n" +
+				"1,000052998224725,1500.25\n" +
+				"2,026404731000196,10000.00\n",
+		},
+		{
+			name:    "pt-BR with spec headers",
+			options: documenttranslator.SpreadsheetOptions{Naming: documenttranslator.FieldNamingSpec, DecimalStyle: documenttranslator.DecimalStylePtBR},
+			expected: "line_number;cpf_cnpj;valor\n" +
+				"1;000052998224725;1500,25\n" +
+				"2;026404731000196;10000,00\n",
+		},
+		{
+			name:    "pt-BR with a custom separator",
+			options: documenttranslator.SpreadsheetOptions{Naming: documenttranslator.FieldNamingSnakeCase, DecimalStyle: documenttranslator.DecimalStylePtBR, Comma: '\t'},
+			expected: "line_number\tdocument_number\tamount\n" +
+				"1\t000052998224725\t1500,25\n" +
+				"2\t026404731000196\t10000,00\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := documenttranslator.ExportCSV(documenttranslator.FormatBradescoRating, strings.NewReader(strings.Join(ratingLines, "\r\n")), test.options)
+
+			assert.NoError(t, err)
+			assert.Len(t, files, 1)
+			assert.Equal(t, test.expected, string(files["rating"]))
+		})
+	}
+}
+
+func TestExportCSVRecordTypes(t *testing.T) {
+	files, err := documenttranslator.ExportCSV(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(brf240Lines, "\n")), documenttranslator.SpreadsheetOptions{
+		DecimalStyle: documenttranslator.DecimalStylePtBR,
+		DateLayout:   "02/01/2006",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, files, 5)
+
+	segments := strings.Split(strings.TrimSpace(string(files["3A"])), "\n")
+	assert.Len(t, segments, 2)
+	assert.True(t, strings.HasPrefix(segments[0], "LineNumber;BankCode;BatchNumber;RegistryKind;"))
+	assert.Contains(t, segments[1], ";03/06/2019;03/06/2019;9523,57;")
+
+	header := strings.Split(strings.TrimSpace(string(files["0"])), "\n")
+	assert.Contains(t, header[1], ";03/06/2019;")
+
+	_, err = documenttranslator.ExportCSV(documenttranslator.Format("cnab400"), strings.NewReader(brf240Lines[0]), documenttranslator.SpreadsheetOptions{})
+	assert.ErrorIs(t, err, documenttranslator.ErrUnregisteredFormat)
+}
+
+func TestExportCSVOccursColumns(t *testing.T) {
+	y52 := "2370001300001Y 00520000000000120510000000004431823009202341230900766315001035570200000120511001205197" + strings.Repeat(" ", 139)
+
+	files, err := documenttranslator.ExportCSV(documenttranslator.FormatBRF240, strings.NewReader(y52), documenttranslator.SpreadsheetOptions{Naming: documenttranslator.FieldNamingSnakeCase})
+	assert.NoError(t, err)
+
+	rows := strings.Split(strings.TrimSpace(string(files["3Y52"])), "\n")
+	assert.Len(t, rows, 2)
+	assert.True(t, strings.HasSuffix(rows[0], ",fiscal_documents.1.number,fiscal_documents.1.value,fiscal_documents.1.date,fiscal_documents.1.key,"+
+		"fiscal_documents.2.number,fiscal_documents.2.value,fiscal_documents.2.date,fiscal_documents.2.key"))
+	assert.True(t, strings.HasSuffix(rows[1], ",12051,443182,30092023,41230900766315001035570200000120511001205197,,,,"))
+}
+
+// sparseRecord is a record parsed as a nil pointer from lines holding no values.
+type sparseRecord struct {
+	Name   string `translator:"part:1..5"`
+	Amount int64  `translator:"part:6..10"`
+}
+
+func (r *sparseRecord) String() (string, error) {
+	return "", nil
+}
+
+func TestExportCSVHeadersFromRecordType(t *testing.T) {
+	documenttranslator.Register(documenttranslator.FormatDefinition{
+		Name: "sparse",
+		Kind: func(line string) (string, error) { return "record", nil },
+		Parse: func(line string) (documenttranslator.Record, error) {
+			if strings.TrimSpace(line) == "-" {
+				return (*sparseRecord)(nil), nil
+			}
+
+			return &sparseRecord{Name: line[:5], Amount: 42}, nil
+		},
+	})
+
+	files, err := documenttranslator.ExportCSV("sparse", strings.NewReader("-\nALICE00042\n"), documenttranslator.SpreadsheetOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "LineNumber,Name,Amount\n1,,\n2,ALICE,42\n", string(files["record"]))
+}
+
+func TestExportXLSX(t *testing.T) {
+	var workbook bytes.Buffer
+
+	err := documenttranslator.ExportXLSX(documenttranslator.FormatBRF240, strings.NewReader(strings.Join(brf240Lines, "\n")), &workbook, documenttranslator.SpreadsheetOptions{})
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(workbook.Bytes()), int64(workbook.Len()))
+	assert.NoError(t, err)
+
+	parts := map[string]string{}
+
+	for _, file := range archive.File {
+		entry, err := file.Open()
+		assert.NoError(t, err)
+
+		content, err := io.ReadAll(entry)
+		assert.NoError(t, err)
+
+		parts[file.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "_rels/.rels")
+	assert.Contains(t, parts, "xl/_rels/workbook.xml.rels")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="0" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="3A" sheetId="3" r:id="rId3"/>`)
+	assert.Contains(t, parts["[Content_Types].xml"], `/xl/worksheets/sheet5.xml`)
+
+	segments := parts["xl/worksheets/sheet3.xml"]
+	assert.Contains(t, segments, `<c r="A1" t="inlineStr"><is><t>LineNumber</t></is></c>`)
+	assert.Contains(t, segments, `<c r="A2"><v>3</v></c>`)
+	assert.Contains(t, segments, `<v>9523.57</v>`)
+	assert.Contains(t, segments, `<t xml:space="preserve">2019-06-03</t>`)
+	assert.Contains(t, segments, `<t xml:space="preserve">033</t>`)
+}
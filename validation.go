@@ -0,0 +1,44 @@
+package documenttranslator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrValidation = errors.New("record failed validation")
+
+// FieldError describes a field value rejected by one of its validation rules.
+type FieldError struct {
+	Struct string      // Struct is the type name of the validated record.
+	Field  string      // Field is the path of the field inside the record, e.g. "Account.Agency".
+	Rule   string      // Rule is the validation rule as declared in the tag, e.g. "oneof:1,2".
+	Value  interface{} // Value is the rejected field value.
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("field %s.%s with value %v does not satisfy %s", e.Struct, e.Field, e.Value, e.Rule)
+}
+
+// ValidationErrors holds every field of a record rejected by its validation rules.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	var messages []string
+
+	for _, fieldError := range v {
+		messages = append(messages, fieldError.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (v ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// RecordValidator is implemented by records whose rules span several fields, such as bank
+// account check digits. ValidateRecord returns the rejected fields, or nil when the record is valid.
+type RecordValidator interface {
+	ValidateRecord() ValidationErrors
+}